@@ -8,36 +8,248 @@ import (
 	"github.com/rs/zerolog/log"
 	"os"
 	"strings"
+	"sync"
+)
+
+var (
+	configReloadCallbacks      []func()
+	configReloadCallbacksMutex sync.Mutex
+
+	dotenvFiles      []string
+	dotenvValues     map[string]string
+	dotenvValuesLock sync.RWMutex
+
+	secretFilesEnabled     bool
+	secretFilesEnabledLock sync.RWMutex
+
+	loadedConfigFiles   []string
+	loadedRemoteSources []fetchedSource
+	configSourcesLock   sync.Mutex
 )
 
 // LoadConfig loads configuration from environment variables and optionally from the specified list of files.
-// YAML, JSON and HCL file formats are supported.
+// YAML, JSON and HCL file formats are supported, as well as .env files. Variables from .env files are treated
+// just like environment variables, but the actual environment variables take precedence over them.
+// Variables named NAME_FILE can additionally provide the value of NAME from a file, see EnableSecretFiles.
 // Configuration is stored into global config.Config instance.
+// Use NewConfigWatcher to reload the files automatically whenever they change.
 func LoadConfig(files ...string) (loaded bool) {
 	loaded = true
 
-	if len(files) > 0 {
-		config.AddDriver(yamlv3.Driver)
-		config.AddDriver(json.Driver)
-		config.AddDriver(toml.Driver)
+	var configFiles []string
+	var envFiles []string
+	for _, file := range files {
+		if isDotenvFile(file) {
+			envFiles = append(envFiles, file)
+		} else {
+			configFiles = append(configFiles, file)
+		}
+	}
+
+	configSourcesLock.Lock()
+	loadedConfigFiles = configFiles
+	configSourcesLock.Unlock()
 
-		err := config.LoadFiles(files...)
+	if len(configFiles) > 0 {
+		addConfigDrivers()
+
+		err := config.LoadFiles(configFiles...)
 		if err != nil {
 			log.Warn().Err(err).Msg("Failed to load configuration files")
 			loaded = false
 		}
 	}
 
+	if len(envFiles) > 0 {
+		dotenvFiles = envFiles
+
+		if err := loadDotenvFiles(); err != nil {
+			log.Warn().Err(err).Msg("Failed to load .env files")
+			loaded = false
+		}
+	}
+
+	loadEnvs()
+	saveConfigSnapshot()
+	return
+}
+
+// EnableSecretFiles enables the convention of passing Docker and Kubernetes secrets in files. For each variable
+// named NAME_FILE, the content of the file it points to is loaded as the value of NAME, unless NAME is set directly.
+// The NAME_FILE variable itself is still loaded as usual, unless its key is overwritten by the value of NAME.
+// It should be called before LoadConfig.
+func EnableSecretFiles() {
+	secretFilesEnabledLock.Lock()
+	defer secretFilesEnabledLock.Unlock()
+
+	secretFilesEnabled = true
+}
+
+// ReloadConfig re-reads configuration files loaded by LoadConfig and invokes callbacks registered with
+// OnConfigReload. The configuration is rebuilt from scratch, so the keys removed from the files are dropped,
+// values from remote sources loaded with LoadRemoteConfig still override the files, and environment variables
+// override all of them. If any of the files fails to load, the previous configuration is retained
+// and the callbacks are not invoked.
+func ReloadConfig() bool {
+	configSourcesLock.Lock()
+	remoteSources := loadedRemoteSources
+	configSourcesLock.Unlock()
+
+	if err := rebuildConfig(remoteSources); err != nil {
+		log.Warn().Err(err).Msg("Failed to reload configuration files")
+		return false
+	}
+
+	fireConfigReloadCallbacks()
+
+	return true
+}
+
+// rebuildConfig replaces the global configuration with the one built from the files loaded by LoadConfig,
+// followed by given remote sources and environment variables. The global configuration is not modified on error.
+func rebuildConfig(remoteSources []fetchedSource) error {
+	addConfigDrivers()
+
+	configSourcesLock.Lock()
+	files := loadedConfigFiles
+	configSourcesLock.Unlock()
+
+	staged := config.New("staged")
+	staged.WithDriver(yamlv3.Driver, json.Driver, toml.Driver)
+
+	if len(files) > 0 {
+		if err := staged.LoadFiles(files...); err != nil {
+			return err
+		}
+	}
+
+	for _, source := range remoteSources {
+		if err := staged.LoadSources(source.format, source.data); err != nil {
+			return err
+		}
+	}
+
+	if err := loadDotenvFiles(); err != nil {
+		return err
+	}
+
+	config.Default().ClearData()
+	config.Default().ClearCaches()
+	if err := config.LoadData(staged.Data()); err != nil {
+		return err
+	}
+
+	configSourcesLock.Lock()
+	loadedRemoteSources = remoteSources
+	configSourcesLock.Unlock()
+
+	loadEnvs()
+	return nil
+}
+
+// OnConfigReload registers a callback invoked each time the configuration is reloaded, either with ReloadConfig
+// or by RemoteConfigWatcher. The changes introduced by the reload are logged and available through LastConfigChanges.
+func OnConfigReload(callback func()) {
+	configReloadCallbacksMutex.Lock()
+	defer configReloadCallbacksMutex.Unlock()
+
+	configReloadCallbacks = append(configReloadCallbacks, callback)
+}
+
+func fireConfigReloadCallbacks() {
+	logConfigChanges()
+
+	configReloadCallbacksMutex.Lock()
+	callbacks := configReloadCallbacks
+	configReloadCallbacksMutex.Unlock()
+
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
+func addConfigDrivers() {
+	for _, driver := range []config.Driver{yamlv3.Driver, json.Driver, toml.Driver} {
+		if !config.Default().HasDecoder(driver.Name()) {
+			config.AddDriver(driver)
+		}
+	}
+}
+
+func loadDotenvFiles() error {
+	values := map[string]string{}
+
+	for _, file := range dotenvFiles {
+		if err := parseDotenvFile(file, values); err != nil {
+			return err
+		}
+	}
+
+	dotenvValuesLock.Lock()
+	dotenvValues = values
+	dotenvValuesLock.Unlock()
+
+	return nil
+}
+
+func loadEnvs() {
 	envs := map[string]string{}
+
+	dotenvValuesLock.RLock()
+	for name, value := range dotenvValues {
+		envs[name] = value
+	}
+	dotenvValuesLock.RUnlock()
+
 	for _, env := range os.Environ() {
 		s := strings.SplitN(env, "=", 2)
-		envName := s[0]
+		envs[s[0]] = s[1]
+	}
 
-		envs[envName] = envNameToConfigKey(envName)
+	secretFilesEnabledLock.RLock()
+	resolveSecrets := secretFilesEnabled
+	secretFilesEnabledLock.RUnlock()
+
+	var secrets map[string]string
+	if resolveSecrets {
+		secrets = resolveSecretFiles(envs)
 	}
 
-	config.LoadOSEnvs(envs)
-	return
+	for name, value := range envs {
+		if value != "" {
+			_ = config.Set(envNameToConfigKey(name), value)
+		}
+	}
+
+	// secrets are set last, as NAME and NAME_FILE map to overlapping keys
+	for name, value := range secrets {
+		_ = config.Set(envNameToConfigKey(name), value)
+	}
+}
+
+func resolveSecretFiles(envs map[string]string) map[string]string {
+	secrets := map[string]string{}
+
+	for name, value := range envs {
+		if !strings.HasSuffix(name, "_FILE") || value == "" {
+			continue
+		}
+
+		baseName := strings.TrimSuffix(name, "_FILE")
+		if _, ok := envs[baseName]; ok {
+			continue
+		}
+
+		content, err := os.ReadFile(value)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed to read file referenced by %s", name)
+			continue
+		}
+
+		secrets[baseName] = strings.TrimRight(string(content), "\r\n")
+	}
+
+	return secrets
 }
 
 func envNameToConfigKey(envName string) string {
@@ -0,0 +1,246 @@
+package tiny
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gookit/config/v2"
+)
+
+var configValidator = validator.New()
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// ConfigError denotes an error in binding a single configuration key.
+type ConfigError struct {
+	// Key is a full configuration key of the field.
+	Key string
+
+	// Err is an original error.
+	Err error
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Key, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// ConfigErrors is a list of errors returned by BindConfig.
+type ConfigErrors []*ConfigError
+
+func (e ConfigErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// BindConfig maps the configuration keys under given prefix onto fields of the struct pointed by out.
+// The key of each field is read from the `config` tag (default: lowercase name of the field).
+// The value of `default` tag is used when the key is missing, and the `validate` tag is checked
+// with go-playground/validator afterwards. Nested structs are bound recursively.
+// All the problems found are returned together as ConfigErrors.
+func BindConfig(prefix string, out any) error {
+	value := reflect.ValueOf(out)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return errors.New("BindConfig expects a pointer to struct")
+	}
+
+	var errs ConfigErrors
+	bindStruct(prefix, value.Elem(), &errs)
+
+	if err := configValidator.Struct(out); err != nil {
+		var validationErrors validator.ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			return err
+		}
+
+		for _, fieldError := range validationErrors {
+			errs = append(errs, &ConfigError{
+				Key: joinConfigKey(prefix, strings.Join(strings.Split(fieldError.Namespace(), ".")[1:], ".")),
+				Err: fmt.Errorf("failed on '%s' validation", fieldError.Tag()),
+			})
+		}
+	}
+
+	if errs != nil {
+		return errs
+	}
+
+	return nil
+}
+
+func bindStruct(prefix string, value reflect.Value, errs *ConfigErrors) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := configFieldName(field)
+		if name == "" {
+			continue
+		}
+		key := joinConfigKey(prefix, name)
+		fieldValue := value.Field(i)
+
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{}) {
+			bindStruct(key, fieldValue, errs)
+			continue
+		}
+
+		raw, ok := lookupConfigValue(key)
+		if !ok {
+			defaultValue, hasDefault := field.Tag.Lookup("default")
+			if !hasDefault {
+				continue
+			}
+
+			raw = defaultValue
+		}
+
+		if err := setConfigValue(fieldValue, raw); err != nil {
+			*errs = append(*errs, &ConfigError{Key: key, Err: err})
+		}
+	}
+}
+
+func lookupConfigValue(key string) (any, bool) {
+	if value, ok := config.GetValue(key); ok {
+		return value, true
+	}
+
+	// keys loaded from environment variables are always lowercase
+	return config.GetValue(strings.ToLower(key))
+}
+
+func setConfigValue(field reflect.Value, raw any) error {
+	if field.Kind() == reflect.Pointer {
+		value := reflect.New(field.Type().Elem())
+		if err := setConfigValue(value.Elem(), raw); err != nil {
+			return err
+		}
+
+		field.Set(value)
+		return nil
+	}
+
+	if field.Type() == durationType {
+		duration, err := time.ParseDuration(fmt.Sprint(raw))
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(int64(duration))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(fmt.Sprint(raw))
+	case reflect.Bool:
+		value, err := strconv.ParseBool(fmt.Sprint(raw))
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(fmt.Sprint(raw), 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := strconv.ParseUint(fmt.Sprint(raw), 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(fmt.Sprint(raw), field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetFloat(value)
+	case reflect.Slice:
+		var items []any
+		switch v := raw.(type) {
+		case []any:
+			items = v
+		case string:
+			for _, item := range strings.Split(v, ",") {
+				items = append(items, strings.TrimSpace(item))
+			}
+		default:
+			items = []any{v}
+		}
+
+		slice := reflect.MakeSlice(field.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setConfigValue(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+
+		field.Set(slice)
+	case reflect.Map:
+		values, ok := raw.(map[string]any)
+		if !ok || field.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("cannot convert %T to %v", raw, field.Type())
+		}
+
+		m := reflect.MakeMapWithSize(field.Type(), len(values))
+		for k, v := range values {
+			item := reflect.New(field.Type().Elem()).Elem()
+			if err := setConfigValue(item, v); err != nil {
+				return err
+			}
+
+			m.SetMapIndex(reflect.ValueOf(k).Convert(field.Type().Key()), item)
+		}
+
+		field.Set(m)
+	default:
+		return fmt.Errorf("unsupported field type %v", field.Type())
+	}
+
+	return nil
+}
+
+func configFieldName(field reflect.StructField) string {
+	tag := field.Tag.Get("config")
+	if tag == "-" {
+		return ""
+	}
+	if tag != "" {
+		return tag
+	}
+
+	return strings.ToLower(field.Name)
+}
+
+func joinConfigKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
+
+func init() {
+	configValidator.RegisterTagNameFunc(configFieldName)
+}
@@ -0,0 +1,39 @@
+package tiny
+
+import (
+	"github.com/gookit/config/v2"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type serverConfig struct {
+	Address string        `config:"address" default:"0.0.0.0:8080"`
+	Timeout time.Duration `config:"timeout" default:"5s"`
+	Origins []string      `config:"origins"`
+	Limits  struct {
+		MaxConnections int `config:"maxConnections" validate:"gte=1"`
+	} `config:"limits"`
+}
+
+func TestBindConfig(t *testing.T) {
+	// given
+	_ = config.LoadData(map[string]any{
+		"server": map[string]any{
+			"timeout": "10s",
+			"origins": "a.example.com, b.example.com",
+		},
+	})
+	defer config.ClearAll()
+
+	var c serverConfig
+
+	// when
+	err := BindConfig("server", &c)
+
+	// then
+	assert.EqualError(t, err, "server.limits.maxConnections: failed on 'gte' validation", "validation error should be returned")
+	assert.Equal(t, "0.0.0.0:8080", c.Address, "default value should be used")
+	assert.Equal(t, 10*time.Second, c.Timeout, "duration should be parsed")
+	assert.Equal(t, []string{"a.example.com", "b.example.com"}, c.Origins, "list should be split")
+}
@@ -0,0 +1,299 @@
+package tiny
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var remoteConfigClient = &http.Client{Timeout: 10 * time.Second}
+
+// ConfigSource is a remote source of configuration, such as an HTTP endpoint or a key in Consul KV.
+type ConfigSource interface {
+	// Fetch retrieves the raw configuration and its format (yaml, json or toml).
+	Fetch() (data []byte, format string, err error)
+}
+
+// HTTPSource is a ConfigSource reading a configuration file from a URL.
+type HTTPSource struct {
+	// URL is an address of the configuration file.
+	URL string
+
+	// Format is a format of the file (default: inferred from URL extension, or "json").
+	Format string
+
+	// Headers is a set of headers attached to the request, for example authorization tokens.
+	Headers map[string]string
+}
+
+// ConsulSource is a ConfigSource reading a configuration file from Consul KV store.
+type ConsulSource struct {
+	// Address is an address of the Consul agent, for example "http://localhost:8500".
+	Address string
+
+	// Key is a key holding the configuration file.
+	Key string
+
+	// Token is an ACL token used to authorize requests to Consul.
+	Token string
+
+	// Format is a format of the file (default: inferred from key extension, or "json").
+	Format string
+}
+
+// EtcdSource is a ConfigSource reading a configuration file from etcd, using its v3 HTTP gateway.
+type EtcdSource struct {
+	// Address is an address of the etcd gateway, for example "http://localhost:2379".
+	Address string
+
+	// Key is a key holding the configuration file.
+	Key string
+
+	// Format is a format of the file (default: inferred from key extension, or "json").
+	Format string
+}
+
+// Fetch implements the interface of ConfigSource.
+func (s *HTTPSource) Fetch() ([]byte, string, error) {
+	request, err := http.NewRequest(http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for key, value := range s.Headers {
+		request.Header.Set(key, value)
+	}
+
+	data, err := sendConfigRequest(request)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, resolveConfigFormat(s.Format, s.URL), nil
+}
+
+// Fetch implements the interface of ConfigSource.
+func (s *ConsulSource) Fetch() ([]byte, string, error) {
+	requestURL := strings.TrimSuffix(s.Address, "/") + "/v1/kv/" + strings.TrimPrefix(s.Key, "/") + "?raw"
+
+	request, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if s.Token != "" {
+		request.Header.Set("X-Consul-Token", s.Token)
+	}
+
+	data, err := sendConfigRequest(request)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, resolveConfigFormat(s.Format, s.Key), nil
+}
+
+// Fetch implements the interface of ConfigSource.
+func (s *EtcdSource) Fetch() ([]byte, string, error) {
+	body, err := json.Marshal(map[string]string{
+		"key": base64.StdEncoding.EncodeToString([]byte(s.Key)),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	request, err := http.NewRequest(
+		http.MethodPost,
+		strings.TrimSuffix(s.Address, "/")+"/v3/kv/range",
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	responseBody, err := sendConfigRequest(request)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var response struct {
+		Kvs []struct {
+			Value string `json:"value"`
+		} `json:"kvs"`
+	}
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, "", err
+	}
+
+	if len(response.Kvs) == 0 {
+		return nil, "", fmt.Errorf("key %s not found in etcd", s.Key)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(response.Kvs[0].Value)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, resolveConfigFormat(s.Format, s.Key), nil
+}
+
+// LoadRemoteConfig loads configuration from given remote sources, in order. Values from the remote sources
+// override the files loaded by LoadConfig, values from the latter sources override the former ones,
+// and environment variables override all of them. The sources are retained, so ReloadConfig keeps their values.
+func LoadRemoteConfig(sources ...ConfigSource) error {
+	fetched, _, err := fetchSources(sources)
+	if err != nil {
+		return err
+	}
+
+	if err := rebuildConfig(fetched); err != nil {
+		return err
+	}
+
+	saveConfigSnapshot()
+	return nil
+}
+
+// RemoteConfigWatcherConfig holds a configuration for NewRemoteConfigWatcher.
+type RemoteConfigWatcherConfig struct {
+	// Interval is a time between subsequent fetches of the sources (default: 30s).
+	Interval time.Duration
+}
+
+// RemoteConfigWatcher is a Service that periodically fetches remote configuration sources and reloads
+// the configuration when any of them changes. Callbacks registered with OnConfigReload are invoked after each reload.
+type RemoteConfigWatcher struct {
+	sources     []ConfigSource
+	config      *RemoteConfigWatcherConfig
+	stopChannel chan struct{}
+}
+
+// NewRemoteConfigWatcher creates new RemoteConfigWatcher for given sources.
+func NewRemoteConfigWatcher(sources []ConfigSource, config ...*RemoteConfigWatcherConfig) *RemoteConfigWatcher {
+	var providedConfig *RemoteConfigWatcherConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := &RemoteConfigWatcherConfig{
+		Interval: 30 * time.Second,
+	}
+	if providedConfig != nil && providedConfig.Interval > 0 {
+		c.Interval = providedConfig.Interval
+	}
+
+	return &RemoteConfigWatcher{
+		sources:     sources,
+		config:      c,
+		stopChannel: make(chan struct{}, 1),
+	}
+}
+
+// Start implements the interface of Service.
+func (w *RemoteConfigWatcher) Start() error {
+	_, checksum, _ := fetchSources(w.sources)
+
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChannel:
+			return nil
+		case <-ticker.C:
+			fetched, current, err := fetchSources(w.sources)
+			if err != nil {
+				log.Warn().Err(err).Msg("Failed to fetch remote configuration")
+				continue
+			}
+			if current == checksum {
+				continue
+			}
+
+			if err := rebuildConfig(fetched); err != nil {
+				log.Warn().Err(err).Msg("Failed to reload remote configuration")
+				continue
+			}
+
+			checksum = current
+			log.Info().Msg("Remote configuration changed, reloaded")
+			fireConfigReloadCallbacks()
+		}
+	}
+}
+
+// Stop implements the interface of Service.
+func (w *RemoteConfigWatcher) Stop() {
+	select {
+	case w.stopChannel <- struct{}{}:
+	default:
+	}
+}
+
+type fetchedSource struct {
+	data   []byte
+	format string
+}
+
+// fetchSources retrieves all the sources and computes a checksum of their contents.
+// Fetching fails if any of the sources fails, so a partial configuration is never loaded.
+func fetchSources(sources []ConfigSource) ([]fetchedSource, string, error) {
+	fetched := make([]fetchedSource, 0, len(sources))
+	hash := sha256.New()
+
+	for _, source := range sources {
+		data, format, err := source.Fetch()
+		if err != nil {
+			return nil, "", err
+		}
+
+		fetched = append(fetched, fetchedSource{data: data, format: format})
+		_, _ = hash.Write(data)
+	}
+
+	return fetched, string(hash.Sum(nil)), nil
+}
+
+func sendConfigRequest(request *http.Request) ([]byte, error) {
+	response, err := remoteConfigClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d", request.URL.Redacted(), response.StatusCode)
+	}
+
+	return io.ReadAll(response.Body)
+}
+
+func resolveConfigFormat(format, location string) string {
+	if format != "" {
+		return format
+	}
+
+	if u, err := url.Parse(location); err == nil {
+		location = u.Path
+	}
+
+	switch ext := strings.TrimPrefix(path.Ext(location), "."); ext {
+	case "yaml", "yml", "json", "toml":
+		return ext
+	default:
+		return "json"
+	}
+}
@@ -0,0 +1,194 @@
+package tiny
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gookit/config/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// RedactedValue replaces values of sensitive keys in configuration snapshots.
+const RedactedValue = "<redacted>"
+
+var (
+	redactedKeyPatterns = []string{"password", "passwd", "secret", "token", "apikey", "api_key", "credential", "private"}
+	redactedKeysMutex   sync.RWMutex
+
+	lastConfigSnapshot map[string]any
+	lastConfigChanges  []ConfigChange
+	configSnapshotLock sync.Mutex
+)
+
+// ConfigChange describes a change of a single configuration key between two snapshots.
+type ConfigChange struct {
+	// Key is a full configuration key.
+	Key string
+
+	// Old is a previous value of the key (nil if the key has been added).
+	Old any
+
+	// New is a current value of the key (nil if the key has been removed).
+	New any
+}
+
+func (c ConfigChange) String() string {
+	switch {
+	case c.Old == nil:
+		return fmt.Sprintf("%s: added %v", c.Key, c.New)
+	case c.New == nil:
+		return fmt.Sprintf("%s: removed", c.Key)
+	default:
+		return fmt.Sprintf("%s: %v -> %v", c.Key, c.Old, c.New)
+	}
+}
+
+// RedactConfigKeys adds patterns of configuration keys whose values should be redacted in snapshots.
+// A key is redacted if it contains any of the patterns, case-insensitively. By default, keys containing
+// words such as "password", "secret" or "token" are redacted.
+func RedactConfigKeys(patterns ...string) {
+	redactedKeysMutex.Lock()
+	defer redactedKeysMutex.Unlock()
+
+	for _, pattern := range patterns {
+		redactedKeyPatterns = append(redactedKeyPatterns, strings.ToLower(pattern))
+	}
+}
+
+// ConfigSnapshot exports the currently loaded configuration (files, remote sources and environment variables)
+// as a flat map of full keys to values. Values of sensitive keys are replaced with RedactedValue.
+func ConfigSnapshot() map[string]any {
+	snapshot := rawConfigSnapshot()
+
+	for key := range snapshot {
+		if isRedactedConfigKey(key) {
+			snapshot[key] = RedactedValue
+		}
+	}
+
+	return snapshot
+}
+
+// DiffConfig computes changes between two snapshots returned by ConfigSnapshot, sorted by key.
+func DiffConfig(before, after map[string]any) []ConfigChange {
+	var changes []ConfigChange
+
+	for key, oldValue := range before {
+		newValue, ok := after[key]
+		if !ok {
+			changes = append(changes, ConfigChange{Key: key, Old: oldValue})
+		} else if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, ConfigChange{Key: key, Old: oldValue, New: newValue})
+		}
+	}
+
+	for key, newValue := range after {
+		if _, ok := before[key]; !ok {
+			changes = append(changes, ConfigChange{Key: key, New: newValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+
+	return changes
+}
+
+// rawConfigSnapshot exports the currently loaded configuration as a flat map, without redacting any values.
+func rawConfigSnapshot() map[string]any {
+	snapshot := map[string]any{}
+	flattenConfig("", config.Data(), snapshot)
+
+	return snapshot
+}
+
+// LastConfigChanges returns the changes introduced by the most recent configuration reload.
+func LastConfigChanges() []ConfigChange {
+	configSnapshotLock.Lock()
+	defer configSnapshotLock.Unlock()
+
+	return append([]ConfigChange(nil), lastConfigChanges...)
+}
+
+// saveConfigSnapshot remembers the current configuration, as a base for computing changes after reload.
+// The values are kept unredacted, so changes of sensitive keys are detected too.
+func saveConfigSnapshot() {
+	snapshot := rawConfigSnapshot()
+
+	configSnapshotLock.Lock()
+	defer configSnapshotLock.Unlock()
+
+	lastConfigSnapshot = snapshot
+}
+
+// logConfigChanges computes and logs the changes since the last snapshot.
+// Values of sensitive keys are redacted in the reported changes.
+func logConfigChanges() {
+	snapshot := rawConfigSnapshot()
+
+	configSnapshotLock.Lock()
+	changes := redactConfigChanges(DiffConfig(lastConfigSnapshot, snapshot))
+	lastConfigSnapshot = snapshot
+	lastConfigChanges = changes
+	configSnapshotLock.Unlock()
+
+	for _, change := range changes {
+		log.Info().
+			Str("key", change.Key).
+			Interface("old", change.Old).
+			Interface("new", change.New).
+			Msg("Configuration changed")
+	}
+}
+
+func redactConfigChanges(changes []ConfigChange) []ConfigChange {
+	for i := range changes {
+		if !isRedactedConfigKey(changes[i].Key) {
+			continue
+		}
+
+		if changes[i].Old != nil {
+			changes[i].Old = RedactedValue
+		}
+		if changes[i].New != nil {
+			changes[i].New = RedactedValue
+		}
+	}
+
+	return changes
+}
+
+func flattenConfig(prefix string, value any, out map[string]any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			flattenConfig(joinConfigKey(prefix, key), item, out)
+		}
+	case map[any]any:
+		for key, item := range v {
+			flattenConfig(joinConfigKey(prefix, fmt.Sprint(key)), item, out)
+		}
+	default:
+		if prefix != "" {
+			out[prefix] = v
+		}
+	}
+}
+
+func isRedactedConfigKey(key string) bool {
+	redactedKeysMutex.RLock()
+	defer redactedKeysMutex.RUnlock()
+
+	key = strings.ToLower(key)
+	for _, pattern := range redactedKeyPatterns {
+		if strings.Contains(key, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,48 @@
+package tiny
+
+import (
+	"github.com/gookit/config/v2"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDiffConfig(t *testing.T) {
+	// given
+	before := map[string]any{"server.port": 8080, "server.host": "localhost", "debug": true}
+	after := map[string]any{"server.port": 9090, "server.host": "localhost", "log.level": "debug"}
+
+	// when
+	changes := DiffConfig(before, after)
+
+	// then
+	assert.Equal(t, []ConfigChange{
+		{Key: "debug", Old: true},
+		{Key: "log.level", New: "debug"},
+		{Key: "server.port", Old: 8080, New: 9090},
+	}, changes, "changes should be detected")
+}
+
+func TestRedactedConfigKey(t *testing.T) {
+	// then
+	assert.True(t, isRedactedConfigKey("db.Password"), "password should be redacted")
+	assert.False(t, isRedactedConfigKey("db.host"), "host should not be redacted")
+}
+
+func TestConfigChangesOfRedactedKey(t *testing.T) {
+	// given
+	_ = config.LoadData(map[string]any{
+		"db": map[string]any{"host": "localhost", "password": "old-secret"},
+	})
+	defer config.ClearAll()
+	saveConfigSnapshot()
+
+	_ = config.Set("db.password", "new-secret")
+
+	// when
+	logConfigChanges()
+
+	// then
+	assert.Equal(t, []ConfigChange{
+		{Key: "db.password", Old: RedactedValue, New: RedactedValue},
+	}, LastConfigChanges(), "change of sensitive key should be reported without its values")
+}
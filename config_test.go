@@ -0,0 +1,69 @@
+package tiny
+
+import (
+	"github.com/gookit/config/v2"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type staticConfigSource struct {
+	data string
+}
+
+func (s *staticConfigSource) Fetch() ([]byte, string, error) {
+	return []byte(s.data), "json", nil
+}
+
+func TestReloadConfigKeepsRemoteValues(t *testing.T) {
+	// given
+	file := filepath.Join(t.TempDir(), "config.yml")
+	_ = os.WriteFile(file, []byte("app:\n  name: file\n  removed: value\n"), 0644)
+
+	LoadConfig(file)
+	defer config.ClearAll()
+
+	err := LoadRemoteConfig(&staticConfigSource{data: `{"app": {"name": "remote"}}`})
+	defer func() {
+		loadedRemoteSources = nil
+	}()
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+
+	_ = os.WriteFile(file, []byte("app:\n  name: changed\n"), 0644)
+
+	// when
+	reloaded := ReloadConfig()
+
+	// then
+	assert.True(t, reloaded, "configuration should be reloaded")
+	assert.Equal(t, "remote", config.String("app.name"), "remote value should override the file")
+	assert.False(t, config.Exists("app.removed"), "key removed from the file should be dropped")
+	assert.Contains(t, LastConfigChanges(), ConfigChange{Key: "app.removed", Old: "value"}, "removal should be reported")
+}
+
+func TestSecretFiles(t *testing.T) {
+	// given
+	secret := filepath.Join(t.TempDir(), "password")
+	_ = os.WriteFile(secret, []byte("s3cret\n"), 0600)
+
+	t.Setenv("DB_PASSWORD_FILE", secret)
+	defer config.ClearAll()
+
+	// when
+	LoadConfig()
+	pathBefore := config.String("db.password.file")
+
+	EnableSecretFiles()
+	defer func() {
+		secretFilesEnabled = false
+	}()
+	LoadConfig()
+
+	// then
+	assert.Equal(t, secret, pathBefore, "path should be loaded as is unless secret files are enabled")
+	assert.Equal(t, "s3cret", config.String("db.password"), "value should be read from the file")
+}
@@ -0,0 +1,128 @@
+package tiny
+
+import (
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ConfigWatcherConfig holds a configuration for NewConfigWatcher.
+type ConfigWatcherConfig struct {
+	// Files is a list of files to watch (default: files loaded by LoadConfig, including .env files).
+	Files []string
+
+	// Interval is a time between subsequent checks of the files (default: 2s).
+	Interval time.Duration
+}
+
+// ConfigWatcher is a Service that monitors configuration files and calls ReloadConfig when any of them changes.
+type ConfigWatcher struct {
+	config      *ConfigWatcherConfig
+	stopChannel chan struct{}
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewConfigWatcher creates new ConfigWatcher.
+func NewConfigWatcher(config ...*ConfigWatcherConfig) *ConfigWatcher {
+	var providedConfig *ConfigWatcherConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeConfigWatcherConfig(providedConfig)
+
+	return &ConfigWatcher{
+		config:      c,
+		stopChannel: make(chan struct{}, 1),
+	}
+}
+
+// Start implements the interface of Service.
+func (w *ConfigWatcher) Start() error {
+	states := w.readStates()
+
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChannel:
+			return nil
+		case <-ticker.C:
+			current := w.readStates()
+			if statesEqual(states, current) {
+				continue
+			}
+
+			states = current
+			log.Info().Msg("Configuration files changed, reloading")
+			ReloadConfig()
+		}
+	}
+}
+
+// Stop implements the interface of Service.
+func (w *ConfigWatcher) Stop() {
+	select {
+	case w.stopChannel <- struct{}{}:
+	default:
+	}
+}
+
+func (w *ConfigWatcher) readStates() map[string]fileState {
+	files := w.config.Files
+	if files == nil {
+		configSourcesLock.Lock()
+		files = append(append([]string{}, loadedConfigFiles...), dotenvFiles...)
+		configSourcesLock.Unlock()
+	}
+
+	states := make(map[string]fileState, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+
+		states[file] = fileState{modTime: info.ModTime(), size: info.Size()}
+	}
+
+	return states
+}
+
+func statesEqual(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for file, state := range a {
+		if b[file] != state {
+			return false
+		}
+	}
+
+	return true
+}
+
+func mergeConfigWatcherConfig(provided *ConfigWatcherConfig) *ConfigWatcherConfig {
+	config := &ConfigWatcherConfig{
+		Interval: 2 * time.Second,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.Files != nil {
+		config.Files = provided.Files
+	}
+	if provided.Interval > 0 {
+		config.Interval = provided.Interval
+	}
+
+	return config
+}
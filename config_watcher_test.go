@@ -0,0 +1,48 @@
+package tiny
+
+import (
+	"github.com/gookit/config/v2"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConfigWatcherReload(t *testing.T) {
+	// given
+	file := filepath.Join(t.TempDir(), "config.yml")
+	_ = os.WriteFile(file, []byte("app:\n  value: old\n"), 0644)
+
+	LoadConfig(file)
+	defer config.ClearAll()
+
+	values := make(chan string, 10)
+	OnConfigReload(func() {
+		select {
+		case values <- config.String("app.value"):
+		default:
+		}
+	})
+
+	watcher := NewConfigWatcher(&ConfigWatcherConfig{
+		Files:    []string{file},
+		Interval: 10 * time.Millisecond,
+	})
+	go func() {
+		_ = watcher.Start()
+	}()
+	defer watcher.Stop()
+
+	// when
+	time.Sleep(20 * time.Millisecond)
+	_ = os.WriteFile(file, []byte("app:\n  value: changed\n"), 0644)
+
+	// then
+	select {
+	case value := <-values:
+		assert.Equal(t, "changed", value, "callback should observe the new value")
+	case <-time.After(2 * time.Second):
+		assert.Fail(t, "callback should be fired after the file changes")
+	}
+}
@@ -2,37 +2,180 @@ package tiny
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/term"
 )
 
 // ConsoleLineHandler specifies a handler function for ConsoleReader.
 type ConsoleLineHandler = func(line string)
 
+// ConsoleCompleter specifies a function returning completion candidates for the partially typed line.
+type ConsoleCompleter = func(line string) []string
+
 // ConsoleReader is a Service that actively reads os.Stdin and passes read lines to the underlying handler.
+// Line editing, history navigation with arrow keys and tab completion can be enabled with LineEditing.
+// Instead of os.Stdin, ConsoleReader can read from any io.Reader (see Input) or from clients connecting
+// to a Unix domain socket (see Socket).
 type ConsoleReader struct {
-	handler ConsoleLineHandler
-	prompt  string
+	handler     ConsoleLineHandler
+	completer   ConsoleCompleter
+	onInterrupt func()
+	onEOF       func()
+	prompt      string
+	output      *consoleOutput
+	input       io.Reader
+	socketPath  string
+	listener    net.Listener
+	listenerMu  sync.Mutex
+	stopped     atomic.Bool
+	restoreOnce sync.Once
+	restoreFunc func()
+	lineEditing bool
 }
 
+var (
+	isTerminal = term.IsTerminal
+	makeRaw    = term.MakeRaw
+)
+
 // NewConsoleReader creates new ConsoleReader.
 func NewConsoleReader(handler ConsoleLineHandler) *ConsoleReader {
 	return &ConsoleReader{
-		handler: handler,
+		handler:     handler,
+		onInterrupt: raiseInterrupt,
+		output:      &consoleOutput{},
 	}
 }
 
 // Prompt sets and enables printing defined prompt before line reading.
 func (c *ConsoleReader) Prompt(prompt string) {
 	c.prompt = prompt
+	c.output.setPrompt(prompt)
+}
+
+// LineEditing enables line editing, history navigation with arrow keys and tab completion, when os.Stdin
+// is an interactive terminal. The terminal is switched to raw mode, so any output must be written through
+// the writer returned by Output - it translates line feeds and redraws the prompt after the output.
+// In this mode Ctrl+C is handled by the function set with OnInterrupt.
+func (c *ConsoleReader) LineEditing() {
+	c.lineEditing = true
+}
+
+// Completer sets a function used for tab completion.
+func (c *ConsoleReader) Completer(completer ConsoleCompleter) {
+	c.completer = completer
+}
+
+// OnInterrupt sets a function called when Ctrl+C is pressed with LineEditing enabled
+// (default: send SIGINT to the current process).
+func (c *ConsoleReader) OnInterrupt(handler func()) {
+	c.onInterrupt = handler
+}
+
+// OnEOF sets a function called when Ctrl+D is pressed on an empty line or the input is closed.
+func (c *ConsoleReader) OnEOF(handler func()) {
+	c.onEOF = handler
+}
+
+// UseCommands configures the reader to dispatch lines to given ConsoleCommands, complete their names
+// and print their output through the reader.
+func (c *ConsoleReader) UseCommands(commands *ConsoleCommands) {
+	c.handler = commands.Handle
+	c.completer = commands.Complete
+	commands.config.Output = c.Output()
+}
+
+// Output returns a writer that should be used to print to the console while the reader is running,
+// for example as tinylog.ConsoleConfig.Output. Writing through it preserves the prompt and the line being edited.
+func (c *ConsoleReader) Output() io.Writer {
+	return c.output
+}
+
+// Input sets a reader the lines are read from instead of os.Stdin. Line editing is not available in this mode.
+func (c *ConsoleReader) Input(reader io.Reader) {
+	c.input = reader
+}
+
+// Socket configures the reader to listen on a Unix domain socket under given path instead of reading os.Stdin.
+// This way an admin console can be attached to a running daemon, for example with `socat - /run/app/console.sock`.
+// Only one client is served at a time - the output written through Output is sent to the connected client.
+func (c *ConsoleReader) Socket(path string) {
+	c.socketPath = path
 }
 
 // Start implements the interface of Service.
 func (c *ConsoleReader) Start() error {
-	scanner := bufio.NewScanner(os.Stdin)
+	if c.socketPath != "" {
+		return c.serveSocket()
+	}
+	if c.input != nil {
+		return c.readInput(c.input, os.Stdout)
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !c.lineEditing || !isTerminal(fd) {
+		return c.readInput(os.Stdin, os.Stdout)
+	}
+
+	state, err := makeRaw(fd)
+	if err != nil {
+		return c.readInput(os.Stdin, os.Stdout)
+	}
+	c.restoreFunc = func() {
+		_ = term.Restore(fd, state)
+	}
+	defer c.restore()
+
+	return c.readTerminal()
+}
+
+// Stop implements the interface of Service.
+func (c *ConsoleReader) Stop() {
+	c.stopped.Store(true)
+
+	switch {
+	case c.socketPath != "":
+		c.listenerMu.Lock()
+		if c.listener != nil {
+			_ = c.listener.Close()
+		}
+		c.listenerMu.Unlock()
+		c.output.closeWriter()
+	case c.input != nil:
+		if closer, ok := c.input.(io.Closer); ok {
+			_ = closer.Close()
+		}
+	default:
+		c.restore()
+		_ = os.Stdin.Close()
+	}
+}
+
+func (c *ConsoleReader) readInput(reader io.Reader, writer io.Writer) error {
+	err := c.readPlain(reader, writer)
+
+	if c.onEOF != nil {
+		c.onEOF()
+	}
+
+	return err
+}
+
+func (c *ConsoleReader) readPlain(reader io.Reader, writer io.Writer) error {
+	scanner := bufio.NewScanner(reader)
 	for {
 		if c.prompt != "" {
-			fmt.Print(c.prompt)
+			_, _ = fmt.Fprint(writer, c.prompt)
 		}
 
 		if !scanner.Scan() {
@@ -46,7 +189,247 @@ func (c *ConsoleReader) Start() error {
 	return scanner.Err()
 }
 
-// Stop implements the interface of Service.
-func (c *ConsoleReader) Stop() {
-	_ = os.Stdin.Close()
+// removeStaleSocket removes a socket left at given path, for example by a previous process.
+// Files other than sockets are kept intact, so binding to the path fails instead.
+func removeStaleSocket(path string) error {
+	if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		return os.Remove(path)
+	}
+
+	return nil
+}
+
+func (c *ConsoleReader) serveSocket() error {
+	if err := removeStaleSocket(c.socketPath); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("unix", c.socketPath)
+	if err != nil {
+		return err
+	}
+	c.listenerMu.Lock()
+	c.listener = listener
+	c.listenerMu.Unlock()
+
+	if c.stopped.Load() {
+		_ = listener.Close()
+	}
+
+	defer func() {
+		_ = listener.Close()
+		_ = removeStaleSocket(c.socketPath)
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if c.stopped.Load() {
+				return nil
+			}
+
+			return err
+		}
+
+		if !c.output.attachWriter(conn) {
+			_, _ = fmt.Fprintln(conn, "Console is already in use")
+			_ = conn.Close()
+			continue
+		}
+
+		go func() {
+			defer c.output.closeWriter()
+
+			if err := c.readPlain(conn, conn); err != nil && !c.stopped.Load() {
+				log.Debug().Err(err).Msg("Console connection closed")
+			}
+		}()
+	}
+}
+
+func (c *ConsoleReader) readTerminal() error {
+	input := &interruptDetector{reader: os.Stdin}
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{input, os.Stdout}, c.prompt)
+
+	if width, height, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		_ = terminal.SetSize(width, height)
+	}
+
+	if c.completer != nil {
+		terminal.AutoCompleteCallback = c.autoComplete
+	}
+
+	c.output.attach(terminal)
+	defer c.output.detach()
+
+	for {
+		line, err := terminal.ReadLine()
+		if err != nil {
+			if errors.Is(err, term.ErrPasteIndicator) {
+				c.handler(line)
+				continue
+			}
+			if errors.Is(err, io.EOF) && input.interrupted() {
+				if c.onInterrupt != nil {
+					c.onInterrupt()
+				}
+				continue
+			}
+			if errors.Is(err, io.EOF) {
+				if c.onEOF != nil {
+					c.onEOF()
+				}
+				return nil
+			}
+
+			return err
+		}
+
+		c.handler(line)
+	}
+}
+
+// autoComplete replaces the word under the cursor with the longest common prefix of the completion candidates.
+func (c *ConsoleReader) autoComplete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+
+	prefix := line[:pos]
+	candidates := c.completer(prefix)
+	if len(candidates) == 0 {
+		return "", 0, false
+	}
+
+	wordStart := strings.LastIndexAny(prefix, " \t") + 1
+
+	completion := commonPrefix(candidates)
+	if len(candidates) == 1 {
+		completion += " "
+	} else if len(completion) <= pos-wordStart {
+		return "", 0, false
+	}
+
+	newLine := prefix[:wordStart] + completion + line[pos:]
+	return newLine, wordStart + len(completion), true
+}
+
+func (c *ConsoleReader) restore() {
+	if c.restoreFunc == nil {
+		return
+	}
+
+	c.restoreOnce.Do(c.restoreFunc)
+}
+
+func commonPrefix(values []string) string {
+	prefix := values[0]
+	for _, value := range values[1:] {
+		for !strings.HasPrefix(value, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	return prefix
+}
+
+func raiseInterrupt() {
+	if process, err := os.FindProcess(os.Getpid()); err == nil {
+		_ = process.Signal(os.Interrupt)
+	}
+}
+
+// interruptDetector remembers whether Ctrl+C has been read, so it can be told apart from Ctrl+D.
+// term.Terminal reports both of them as io.EOF.
+type interruptDetector struct {
+	reader        io.Reader
+	interruptFlag atomic.Bool
+}
+
+func (d *interruptDetector) Read(p []byte) (int, error) {
+	n, err := d.reader.Read(p)
+	if bytes.IndexByte(p[:n], 3) >= 0 {
+		d.interruptFlag.Store(true)
+	}
+
+	return n, err
+}
+
+func (d *interruptDetector) interrupted() bool {
+	return d.interruptFlag.Swap(false)
+}
+
+// consoleOutput writes through the active terminal, so the prompt is redrawn after each write.
+// When a socket client is connected, it writes to the client's connection.
+// Otherwise, it writes to os.Stderr.
+type consoleOutput struct {
+	terminal *term.Terminal
+	writer   io.WriteCloser
+	mutex    sync.RWMutex
+}
+
+func (o *consoleOutput) Write(p []byte) (int, error) {
+	o.mutex.RLock()
+	terminal := o.terminal
+	writer := o.writer
+	o.mutex.RUnlock()
+
+	if writer != nil {
+		return writer.Write(p)
+	}
+	if terminal == nil {
+		return os.Stderr.Write(p)
+	}
+
+	// the terminal translates line endings to CRLF by itself
+	return terminal.Write(p)
+}
+
+func (o *consoleOutput) attach(terminal *term.Terminal) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.terminal = terminal
+}
+
+func (o *consoleOutput) detach() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.terminal = nil
+}
+
+// attachWriter sets the writer of the connected socket client. It returns false if another client is connected.
+func (o *consoleOutput) attachWriter(writer io.WriteCloser) bool {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.writer != nil {
+		return false
+	}
+
+	o.writer = writer
+	return true
+}
+
+func (o *consoleOutput) closeWriter() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.writer != nil {
+		_ = o.writer.Close()
+		o.writer = nil
+	}
+}
+
+func (o *consoleOutput) setPrompt(prompt string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.terminal != nil {
+		o.terminal.SetPrompt(prompt)
+	}
 }
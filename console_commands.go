@@ -0,0 +1,334 @@
+package tiny
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ConsoleCommandHandler specifies a handler function for ConsoleCommand.
+type ConsoleCommandHandler = func(args []string) error
+
+// ConsoleCommand describes a single command recognized by ConsoleCommands.
+type ConsoleCommand struct {
+	// Name is a name used to invoke the command.
+	Name string
+
+	// Aliases is an optional list of alternative names of the command.
+	Aliases []string
+
+	// Usage describes the arguments of the command, for example "<user> [reason]".
+	Usage string
+
+	// Description is a short description of the command displayed by help.
+	Description string
+
+	// MinArgs is a minimal number of arguments accepted by the command.
+	MinArgs int
+
+	// MaxArgs is a maximal number of arguments accepted by the command. Negative value means no limit.
+	// Zero value means the command accepts no arguments beyond MinArgs.
+	MaxArgs int
+
+	// Handler is a function executed when the command is invoked.
+	Handler ConsoleCommandHandler
+
+	// Complete is an optional function returning completion candidates for the last argument.
+	Complete func(args []string) []string
+}
+
+// ConsoleCommandsConfig holds a configuration for NewConsoleCommands.
+type ConsoleCommandsConfig struct {
+	// Output is a writer the help and error messages are printed to (default: os.Stdout).
+	Output io.Writer
+
+	// HistorySize is a maximal number of lines remembered in the history (default: 100).
+	HistorySize int
+
+	// OnUnknown is an optional handler called when the command is not recognized
+	// (default: print an error message).
+	OnUnknown func(name string, args []string)
+}
+
+// ConsoleCommands is a registry of console commands. Its Handle method can be passed to NewConsoleReader.
+// Commands "help" and "history" are registered by default.
+type ConsoleCommands struct {
+	config       *ConsoleCommandsConfig
+	commands     map[string]*ConsoleCommand
+	names        []string
+	history      []string
+	commandsLock sync.RWMutex
+}
+
+// NewConsoleCommands creates new ConsoleCommands.
+func NewConsoleCommands(config ...*ConsoleCommandsConfig) *ConsoleCommands {
+	var providedConfig *ConsoleCommandsConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeConsoleCommandsConfig(providedConfig)
+
+	commands := &ConsoleCommands{
+		config:   c,
+		commands: map[string]*ConsoleCommand{},
+	}
+
+	commands.Register(&ConsoleCommand{
+		Name:        "help",
+		Usage:       "[command]",
+		Description: "Show available commands",
+		MaxArgs:     1,
+		Handler:     commands.help,
+		Complete: func(args []string) []string {
+			return commands.completeName(args[0])
+		},
+	})
+	commands.Register(&ConsoleCommand{
+		Name:        "history",
+		Description: "Show previously entered lines",
+		Handler:     commands.printHistory,
+	})
+
+	return commands
+}
+
+// Register adds a command to the registry, replacing any command registered under the same name.
+func (c *ConsoleCommands) Register(command *ConsoleCommand) {
+	c.commandsLock.Lock()
+	defer c.commandsLock.Unlock()
+
+	if _, exists := c.commands[command.Name]; !exists {
+		c.names = append(c.names, command.Name)
+		sort.Strings(c.names)
+	}
+
+	c.commands[command.Name] = command
+	for _, alias := range command.Aliases {
+		c.commands[alias] = command
+	}
+}
+
+// Handle parses the line and executes the matching command. It implements ConsoleLineHandler.
+func (c *ConsoleCommands) Handle(line string) {
+	args, err := SplitConsoleArgs(line)
+	if err != nil {
+		c.printf("Error: %v\n", err)
+		return
+	}
+	if len(args) == 0 {
+		return
+	}
+
+	c.addToHistory(line)
+
+	c.commandsLock.RLock()
+	command, ok := c.commands[args[0]]
+	c.commandsLock.RUnlock()
+
+	if !ok {
+		if c.config.OnUnknown != nil {
+			c.config.OnUnknown(args[0], args[1:])
+		} else {
+			c.printf("Unknown command: %s. Type \"help\" to see available commands.\n", args[0])
+		}
+
+		return
+	}
+
+	args = args[1:]
+	maxArgs := command.MaxArgs
+	if maxArgs >= 0 && maxArgs < command.MinArgs {
+		maxArgs = command.MinArgs
+	}
+
+	if len(args) < command.MinArgs || (maxArgs >= 0 && len(args) > maxArgs) {
+		c.printf("Usage: %s %s\n", command.Name, command.Usage)
+		return
+	}
+
+	if err := command.Handler(args); err != nil {
+		c.printf("Error: %v\n", err)
+	}
+}
+
+// Complete returns completion candidates for the given, partially typed line.
+// The first word is completed with command names, the following ones by the command's Complete function.
+func (c *ConsoleCommands) Complete(line string) []string {
+	args, err := SplitConsoleArgs(line)
+	if err != nil {
+		return nil
+	}
+
+	if line == "" || unicode.IsSpace(rune(line[len(line)-1])) {
+		args = append(args, "")
+	}
+
+	if len(args) <= 1 {
+		var prefix string
+		if args != nil {
+			prefix = args[0]
+		}
+
+		return c.completeName(prefix)
+	}
+
+	c.commandsLock.RLock()
+	command, ok := c.commands[args[0]]
+	c.commandsLock.RUnlock()
+
+	if !ok || command.Complete == nil {
+		return nil
+	}
+
+	return command.Complete(args[1:])
+}
+
+// History returns previously handled lines, from the oldest.
+func (c *ConsoleCommands) History() []string {
+	c.commandsLock.RLock()
+	defer c.commandsLock.RUnlock()
+
+	return append([]string(nil), c.history...)
+}
+
+func (c *ConsoleCommands) help(args []string) error {
+	c.commandsLock.RLock()
+	defer c.commandsLock.RUnlock()
+
+	if len(args) == 1 {
+		command, ok := c.commands[args[0]]
+		if !ok {
+			return fmt.Errorf("unknown command: %s", args[0])
+		}
+
+		c.printf("%s %s\n  %s\n", command.Name, command.Usage, command.Description)
+		if command.Aliases != nil {
+			c.printf("  Aliases: %s\n", strings.Join(command.Aliases, ", "))
+		}
+
+		return nil
+	}
+
+	for _, name := range c.names {
+		command := c.commands[name]
+		c.printf("  %-30s %s\n", strings.TrimSpace(command.Name+" "+command.Usage), command.Description)
+	}
+
+	return nil
+}
+
+func (c *ConsoleCommands) printHistory(_ []string) error {
+	for i, line := range c.History() {
+		c.printf("%5d  %s\n", i+1, line)
+	}
+
+	return nil
+}
+
+func (c *ConsoleCommands) completeName(prefix string) []string {
+	c.commandsLock.RLock()
+	defer c.commandsLock.RUnlock()
+
+	var candidates []string
+	for _, name := range c.names {
+		if strings.HasPrefix(name, prefix) {
+			candidates = append(candidates, name)
+		}
+	}
+
+	return candidates
+}
+
+func (c *ConsoleCommands) addToHistory(line string) {
+	c.commandsLock.Lock()
+	defer c.commandsLock.Unlock()
+
+	c.history = append(c.history, line)
+	if len(c.history) > c.config.HistorySize {
+		c.history = c.history[len(c.history)-c.config.HistorySize:]
+	}
+}
+
+func (c *ConsoleCommands) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(c.config.Output, format, args...)
+}
+
+// SplitConsoleArgs splits the line into arguments separated by whitespace.
+// Arguments can be quoted with single or double quotes, and a backslash escapes the next character.
+func SplitConsoleArgs(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg := false
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if escaped {
+		return nil, errors.New("trailing backslash")
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
+
+func mergeConsoleCommandsConfig(provided *ConsoleCommandsConfig) *ConsoleCommandsConfig {
+	config := &ConsoleCommandsConfig{
+		Output:      os.Stdout,
+		HistorySize: 100,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.Output != nil {
+		config.Output = provided.Output
+	}
+	if provided.HistorySize > 0 {
+		config.HistorySize = provided.HistorySize
+	}
+	if provided.OnUnknown != nil {
+		config.OnUnknown = provided.OnUnknown
+	}
+
+	return config
+}
@@ -0,0 +1,36 @@
+package tiny
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConsoleCommands(t *testing.T) {
+	// given
+	var output bytes.Buffer
+	var received []string
+
+	commands := NewConsoleCommands(&ConsoleCommandsConfig{Output: &output})
+	commands.Register(&ConsoleCommand{
+		Name:    "kick",
+		Usage:   "<user> [reason]",
+		MinArgs: 1,
+		MaxArgs: 2,
+		Handler: func(args []string) error {
+			received = args
+			return nil
+		},
+	})
+
+	// when
+	commands.Handle(`kick alice "spamming the chat"`)
+	commands.Handle(`kick`)
+	commands.Handle(`ban bob`)
+
+	// then
+	assert.Equal(t, []string{"alice", "spamming the chat"}, received, "arguments should be parsed")
+	assert.Contains(t, output.String(), "Usage: kick <user> [reason]", "usage should be printed")
+	assert.Contains(t, output.String(), "Unknown command: ban", "unknown command should be reported")
+	assert.Equal(t, []string{"help", "history"}, commands.Complete("h"), "command names should be completed")
+}
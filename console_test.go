@@ -0,0 +1,107 @@
+package tiny
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/term"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConsoleReaderInput(t *testing.T) {
+	// given
+	var lines []string
+	reader := NewConsoleReader(func(line string) {
+		lines = append(lines, line)
+	})
+	reader.Input(strings.NewReader("first\nsecond\n"))
+
+	// when
+	err := reader.Start()
+
+	// then
+	assert.Nil(t, err, "reading should succeed")
+	assert.Equal(t, []string{"first", "second"}, lines, "lines should be read from input")
+}
+
+func TestConsoleReaderPlainByDefault(t *testing.T) {
+	// given
+	stdin, stdinWriter, _ := os.Pipe()
+	originalStdin := os.Stdin
+	os.Stdin = stdin
+	defer func() {
+		os.Stdin = originalStdin
+	}()
+
+	originalIsTerminal, originalMakeRaw := isTerminal, makeRaw
+	defer func() {
+		isTerminal, makeRaw = originalIsTerminal, originalMakeRaw
+	}()
+
+	var rawModeEnabled bool
+	isTerminal = func(int) bool {
+		return true
+	}
+	makeRaw = func(fd int) (*term.State, error) {
+		rawModeEnabled = true
+		return nil, errors.New("raw mode is not available")
+	}
+
+	var lines []string
+	reader := NewConsoleReader(func(line string) {
+		lines = append(lines, line)
+	})
+
+	_, _ = stdinWriter.WriteString("first\nsecond\n")
+	_ = stdinWriter.Close()
+
+	// when
+	err := reader.Start()
+
+	// then
+	assert.Nil(t, err, "reading should succeed")
+	assert.False(t, rawModeEnabled, "terminal should not be switched to raw mode without LineEditing")
+	assert.Equal(t, []string{"first", "second"}, lines, "lines should be read with plain scanner")
+}
+
+type bufferTerminal struct {
+	strings.Builder
+}
+
+func (b *bufferTerminal) Read([]byte) (int, error) {
+	return 0, errors.New("not readable")
+}
+
+func TestConsoleOutputLineEndings(t *testing.T) {
+	// given
+	buffer := &bufferTerminal{}
+	output := &consoleOutput{}
+	output.attach(term.NewTerminal(buffer, ""))
+
+	// when
+	n, err := output.Write([]byte("first\nsecond\n"))
+
+	// then
+	assert.NoError(t, err, "write should succeed")
+	assert.Equal(t, 13, n, "whole input should be reported as written")
+	assert.Equal(t, "first\r\nsecond\r\n", buffer.String(), "line endings should be translated once")
+}
+
+func TestConsoleReaderSocketKeepsRegularFile(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "console.sock")
+	_ = os.WriteFile(path, []byte("data"), 0600)
+
+	reader := NewConsoleReader(func(line string) {})
+	reader.Socket(path)
+
+	// when
+	err := reader.Start()
+
+	// then
+	content, _ := os.ReadFile(path)
+	assert.Error(t, err, "listening on a path occupied by a regular file should fail")
+	assert.Equal(t, "data", string(content), "regular file should not be removed")
+}
@@ -0,0 +1,218 @@
+package tiny
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrDependencyCycle is returned when services depend on each other in a cycle.
+var ErrDependencyCycle = errors.New("dependency cycle between services")
+
+// serviceWrapper is implemented by services decorating other services, such as the one returned by DependsOn.
+type serviceWrapper interface {
+	unwrap() Service
+}
+
+type dependentService struct {
+	Service
+
+	dependsOn []Service
+}
+
+// DependsOn declares that the service requires given dependencies to be ready before it can be started.
+// The returned Service should be passed to StartAndBlock in place of the original one.
+// Dependencies are recognized by identity, so services that are not comparable (structs with slices, maps
+// or funcs, passed by value) should be passed by pointer to be shared between multiple dependents.
+func DependsOn(service Service, dependencies ...Service) Service {
+	return &dependentService{
+		Service:   service,
+		dependsOn: dependencies,
+	}
+}
+
+func (d *dependentService) unwrap() Service {
+	return d.Service
+}
+
+// baseService returns the innermost service hidden behind the wrappers.
+func baseService(service Service) Service {
+	for {
+		wrapper, ok := service.(serviceWrapper)
+		if !ok {
+			return service
+		}
+
+		service = wrapper.unwrap()
+	}
+}
+
+// valueService wraps a service that can't be used as a map key, such as a struct with a slice, map or func field
+// passed by value, so it gets a comparable identity.
+type valueService struct {
+	Service
+}
+
+func (v *valueService) unwrap() Service {
+	return v.Service
+}
+
+// comparableService makes sure the service can be used as a map key. Services that are not comparable are wrapped,
+// so every call returns a distinct identity for them.
+func comparableService(service Service) Service {
+	if reflect.TypeOf(service).Comparable() {
+		return service
+	}
+
+	return &valueService{Service: service}
+}
+
+// serviceKey returns the identity of the service, used to recognize it behind the wrappers. It's the innermost
+// service hidden behind the wrappers, unless that one is not comparable.
+func serviceKey(service Service) Service {
+	for {
+		wrapper, ok := service.(serviceWrapper)
+		if !ok {
+			return service
+		}
+
+		next := wrapper.unwrap()
+		if !reflect.TypeOf(next).Comparable() {
+			return service
+		}
+
+		service = next
+	}
+}
+
+// dependenciesOf collects the dependencies declared by all the wrappers of the service.
+func dependenciesOf(service Service) []Service {
+	var dependencies []Service
+
+	for {
+		if dependent, ok := service.(*dependentService); ok {
+			dependencies = append(dependencies, dependent.dependsOn...)
+		}
+
+		wrapper, ok := service.(serviceWrapper)
+		if !ok {
+			return dependencies
+		}
+
+		service = wrapper.unwrap()
+	}
+}
+
+func findReadinessNotifier(service Service) (ReadinessNotifier, bool) {
+	for {
+		if notifier, ok := service.(ReadinessNotifier); ok {
+			return notifier, true
+		}
+
+		wrapper, ok := service.(serviceWrapper)
+		if !ok {
+			return nil, false
+		}
+
+		service = wrapper.unwrap()
+	}
+}
+
+type serviceNode struct {
+	service      Service
+	dependencies []Service
+	level        int
+	visiting     bool
+	visited      bool
+}
+
+// resolveStartupOrder groups services into levels. Services from each level depend only on services
+// from the previous levels, so the levels can be started one after another.
+func resolveStartupOrder(services []Service) ([][]Service, error) {
+	nodes := map[Service]*serviceNode{}
+	registered := map[Service]bool{}
+	var order []Service
+
+	var register func(service Service, explicit bool)
+	register = func(service Service, explicit bool) {
+		service = comparableService(service)
+		key := serviceKey(service)
+
+		node, exists := nodes[key]
+		if !exists {
+			node = &serviceNode{service: service}
+			nodes[key] = node
+			order = append(order, key)
+		} else if explicit && service != key {
+			node.service = service
+		}
+
+		if registered[service] {
+			return
+		}
+		registered[service] = true
+
+		for _, dependency := range dependenciesOf(service) {
+			dependency = comparableService(dependency)
+			node.dependencies = append(node.dependencies, dependency)
+			register(dependency, false)
+		}
+	}
+
+	for _, service := range services {
+		register(service, true)
+	}
+
+	var visit func(key Service) error
+	visit = func(key Service) error {
+		node := nodes[key]
+		if node.visited {
+			return nil
+		}
+		if node.visiting {
+			return ErrDependencyCycle
+		}
+
+		node.visiting = true
+		for _, dependency := range node.dependencies {
+			dependencyKey := serviceKey(dependency)
+			if err := visit(dependencyKey); err != nil {
+				return err
+			}
+
+			if nodes[dependencyKey].level+1 > node.level {
+				node.level = nodes[dependencyKey].level + 1
+			}
+		}
+		node.visiting = false
+		node.visited = true
+
+		return nil
+	}
+
+	var levels [][]Service
+	for _, key := range order {
+		if err := visit(key); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range order {
+		node := nodes[key]
+		for len(levels) <= node.level {
+			levels = append(levels, nil)
+		}
+
+		levels[node.level] = append(levels[node.level], node.service)
+	}
+
+	return levels, nil
+}
+
+func countServices(levels [][]Service) int {
+	var count int
+	for _, level := range levels {
+		count += len(level)
+	}
+
+	return count
+}
@@ -0,0 +1,131 @@
+package tiny
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type dummyService struct {
+	name string
+}
+
+func (d *dummyService) Start() error {
+	return nil
+}
+
+func (d *dummyService) Stop() {
+}
+
+func TestStartupOrder(t *testing.T) {
+	// given
+	database := &dummyService{name: "database"}
+	cache := &dummyService{name: "cache"}
+	worker := &dummyService{name: "worker"}
+	server := &dummyService{name: "server"}
+
+	// when
+	levels, err := resolveStartupOrder([]Service{
+		DependsOn(server, DependsOn(worker, database), cache),
+		database,
+	})
+
+	// then
+	assert.Nil(t, err, "order should be resolved")
+	assert.Len(t, levels, 3, "services should be split into 3 levels")
+	assert.ElementsMatch(t, []Service{database, cache}, levels[0], "first level should contain leaf services")
+	assert.Equal(t, worker, baseService(levels[1][0]), "second level should contain worker")
+	assert.Equal(t, server, baseService(levels[2][0]), "third level should contain server")
+}
+
+func TestDependencyCycle(t *testing.T) {
+	// given
+	first := &dummyService{name: "first"}
+	second := &dummyService{name: "second"}
+
+	// when
+	_, err := resolveStartupOrder([]Service{
+		DependsOn(first, second),
+		DependsOn(second, first),
+	})
+
+	// then
+	assert.ErrorIs(t, err, ErrDependencyCycle, "cycle should be detected")
+}
+
+type startRecordingService struct {
+	blockingService
+	startedChannel chan struct{}
+}
+
+func (s *startRecordingService) Start() error {
+	close(s.startedChannel)
+	return s.blockingService.Start()
+}
+
+func TestRunWaitsForDependencies(t *testing.T) {
+	// given
+	dependency := &notReadyService{
+		blockingService: blockingService{stopChannel: make(chan struct{})},
+		readyChannel:    make(chan struct{}),
+	}
+	dependent := &startRecordingService{
+		blockingService: blockingService{stopChannel: make(chan struct{})},
+		startedChannel:  make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = Run(ctx, DependsOn(dependent, dependency))
+		close(done)
+	}()
+
+	// when
+	var startedBeforeReady bool
+	select {
+	case <-dependent.startedChannel:
+		startedBeforeReady = true
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(dependency.readyChannel)
+
+	// then
+	assert.False(t, startedBeforeReady, "dependent should not be started before its dependency is ready")
+	select {
+	case <-dependent.startedChannel:
+	case <-time.After(time.Second):
+		assert.Fail(t, "dependent should be started after its dependency is ready")
+	}
+
+	cancel()
+	<-done
+}
+
+type uncomparableService struct {
+	tags []string
+}
+
+func (u uncomparableService) Start() error {
+	return nil
+}
+
+func (u uncomparableService) Stop() {
+}
+
+func TestRunUncomparableService(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// then
+	assert.NotPanics(t, func() {
+		_ = Run(
+			ctx,
+			uncomparableService{tags: []string{"first"}},
+			DependsOn(&dummyService{name: "dependent"}, uncomparableService{tags: []string{"second"}}),
+		)
+	}, "services passed by value should be accepted")
+}
@@ -0,0 +1,229 @@
+package tiny
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"sync"
+	"time"
+)
+
+var processStartTime = time.Now()
+
+// DiagnosticsServerConfig holds a configuration for NewDiagnosticsServer.
+type DiagnosticsServerConfig struct {
+	// ExposeConfig decides whether the redacted configuration should be exposed under /debug/config (default: false).
+	ExposeConfig bool
+
+	// ShutdownTimeout is a maximal time to wait for in-flight requests when stopping the server (default: 5s).
+	ShutdownTimeout time.Duration
+}
+
+// DiagnosticsServer is a Service exposing diagnostic endpoints over HTTP. It's meant to be bound to an internal port.
+// The following endpoints are available:
+//   - /debug/pprof/ - profiles provided by net/http/pprof,
+//   - /debug/runtime - runtime statistics, such as number of goroutines and memory usage,
+//   - /debug/services - statuses of the managed services, as returned by Services,
+//   - /debug/config - the loaded configuration, with sensitive values redacted (only if enabled with ExposeConfig),
+//   - /debug/config/changes - changes introduced by the last reload (only if enabled with ExposeConfig).
+//
+// DiagnosticsServer implements ReadinessNotifier - it becomes ready when it starts listening.
+type DiagnosticsServer struct {
+	address      string
+	config       *DiagnosticsServerConfig
+	server       *http.Server
+	readyChannel chan struct{}
+	stopOnce     sync.Once
+}
+
+// RuntimeStats holds runtime statistics reported by DiagnosticsServer.
+type RuntimeStats struct {
+	GoVersion    string        `json:"goVersion"`
+	Uptime       time.Duration `json:"uptime"`
+	NumCPU       int           `json:"numCPU"`
+	GOMAXPROCS   int           `json:"gomaxprocs"`
+	NumGoroutine int           `json:"numGoroutine"`
+	HeapAlloc    uint64        `json:"heapAlloc"`
+	HeapInuse    uint64        `json:"heapInuse"`
+	HeapObjects  uint64        `json:"heapObjects"`
+	Sys          uint64        `json:"sys"`
+	NumGC        uint32        `json:"numGC"`
+	PauseTotal   time.Duration `json:"pauseTotal"`
+}
+
+// NewDiagnosticsServer creates new DiagnosticsServer listening on given address.
+func NewDiagnosticsServer(address string, config ...*DiagnosticsServerConfig) *DiagnosticsServer {
+	var providedConfig *DiagnosticsServerConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeDiagnosticsServerConfig(providedConfig)
+
+	s := &DiagnosticsServer{
+		address:      address,
+		config:       c,
+		readyChannel: make(chan struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/debug/runtime", func(w http.ResponseWriter, _ *http.Request) {
+		writeDiagnosticsJSON(w, ReadRuntimeStats())
+	})
+	mux.HandleFunc("/debug/services", serveServiceStatuses)
+	if c.ExposeConfig {
+		mux.HandleFunc("/debug/config", serveConfig)
+		mux.HandleFunc("/debug/config/changes", serveConfigChanges)
+	}
+
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return s
+}
+
+// ReadRuntimeStats collects current runtime statistics of the process.
+func ReadRuntimeStats() *RuntimeStats {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	return &RuntimeStats{
+		GoVersion:    runtime.Version(),
+		Uptime:       time.Since(processStartTime),
+		NumCPU:       runtime.NumCPU(),
+		GOMAXPROCS:   runtime.GOMAXPROCS(0),
+		NumGoroutine: runtime.NumGoroutine(),
+		HeapAlloc:    memStats.HeapAlloc,
+		HeapInuse:    memStats.HeapInuse,
+		HeapObjects:  memStats.HeapObjects,
+		Sys:          memStats.Sys,
+		NumGC:        memStats.NumGC,
+		PauseTotal:   time.Duration(memStats.PauseTotalNs),
+	}
+}
+
+// Ready implements the interface of ReadinessNotifier.
+func (s *DiagnosticsServer) Ready() <-chan struct{} {
+	return s.readyChannel
+}
+
+// Start implements the interface of Service.
+func (s *DiagnosticsServer) Start() error {
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return err
+	}
+
+	close(s.readyChannel)
+
+	if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Stop implements the interface of Service.
+func (s *DiagnosticsServer) Stop() {
+	s.stopOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+		defer cancel()
+
+		_ = s.server.Shutdown(ctx)
+	})
+}
+
+func serveServiceStatuses(w http.ResponseWriter, _ *http.Request) {
+	type serviceStatus struct {
+		Name      string     `json:"name"`
+		State     string     `json:"state"`
+		StartedAt time.Time  `json:"startedAt"`
+		ReadyAt   *time.Time `json:"readyAt,omitempty"`
+		StoppedAt *time.Time `json:"stoppedAt,omitempty"`
+		Error     string     `json:"error,omitempty"`
+		Restarts  int        `json:"restarts"`
+	}
+
+	optionalTime := func(t time.Time) *time.Time {
+		if t.IsZero() {
+			return nil
+		}
+
+		return &t
+	}
+
+	var statuses []serviceStatus
+	for _, status := range Services() {
+		s := serviceStatus{
+			Name:      status.Name,
+			State:     status.State,
+			StartedAt: status.StartedAt,
+			ReadyAt:   optionalTime(status.ReadyAt),
+			StoppedAt: optionalTime(status.StoppedAt),
+			Restarts:  status.Restarts,
+		}
+		if status.Err != nil {
+			s.Error = status.Err.Error()
+		}
+
+		statuses = append(statuses, s)
+	}
+
+	writeDiagnosticsJSON(w, statuses)
+}
+
+func serveConfig(w http.ResponseWriter, _ *http.Request) {
+	writeDiagnosticsJSON(w, ConfigSnapshot())
+}
+
+func serveConfigChanges(w http.ResponseWriter, _ *http.Request) {
+	type configChange struct {
+		Key string `json:"key"`
+		Old any    `json:"old"`
+		New any    `json:"new"`
+	}
+
+	changes := []configChange{}
+	for _, change := range LastConfigChanges() {
+		changes = append(changes, configChange{Key: change.Key, Old: change.Old, New: change.New})
+	}
+
+	writeDiagnosticsJSON(w, changes)
+}
+
+func writeDiagnosticsJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(value)
+}
+
+func mergeDiagnosticsServerConfig(provided *DiagnosticsServerConfig) *DiagnosticsServerConfig {
+	config := &DiagnosticsServerConfig{
+		ShutdownTimeout: 5 * time.Second,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.ExposeConfig {
+		config.ExposeConfig = true
+	}
+	if provided.ShutdownTimeout > 0 {
+		config.ShutdownTimeout = provided.ShutdownTimeout
+	}
+
+	return config
+}
@@ -0,0 +1,49 @@
+package tiny
+
+import (
+	"encoding/json"
+	"github.com/gookit/config/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiagnosticsServerConfigNotExposedByDefault(t *testing.T) {
+	// given
+	server := NewDiagnosticsServer("127.0.0.1:0")
+
+	// when
+	recorder := httptest.NewRecorder()
+	server.server.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/config", nil))
+
+	// then
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "config should not be exposed by default")
+}
+
+func TestDiagnosticsServerConfigRedacted(t *testing.T) {
+	// given
+	_ = config.LoadData(map[string]any{
+		"db": map[string]any{
+			"host":     "localhost",
+			"password": "secret",
+		},
+	})
+	defer config.ClearAll()
+
+	server := NewDiagnosticsServer("127.0.0.1:0", &DiagnosticsServerConfig{ExposeConfig: true})
+
+	// when
+	recorder := httptest.NewRecorder()
+	server.server.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/config", nil))
+
+	var body map[string]any
+	err := json.Unmarshal(recorder.Body.Bytes(), &body)
+
+	// then
+	assert.Equal(t, http.StatusOK, recorder.Code, "config should be exposed")
+	assert.NoError(t, err, "response should be valid JSON")
+	assert.Equal(t, "localhost", body["db.host"], "regular value should be exposed")
+	assert.Equal(t, RedactedValue, body["db.password"], "sensitive value should be redacted")
+	assert.NotContains(t, recorder.Body.String(), "secret", "sensitive value should not leak")
+}
@@ -0,0 +1,83 @@
+package tiny
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// isDotenvFile reports whether the file should be parsed as a .env file, for example ".env" or ".env.local".
+func isDotenvFile(file string) bool {
+	base := filepath.Base(file)
+	return base == ".env" || strings.HasPrefix(base, ".env.") || filepath.Ext(base) == ".env"
+}
+
+// parseDotenvFile reads KEY=VALUE pairs from the file into values.
+// Lines starting with # are ignored, as well as the optional "export" keyword. Single-quoted values are taken
+// literally, while double-quoted and unquoted values support ${VAR} expansion.
+func parseDotenvFile(file string, values map[string]string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		name, value, found := strings.Cut(line, "=")
+		if !found {
+			return fmt.Errorf("%s:%d: expected KEY=VALUE", file, lineNumber)
+		}
+
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+
+		switch {
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return fmt.Errorf("%s:%d: %w", file, lineNumber, err)
+			}
+
+			value = expandDotenvValue(unquoted, values)
+		default:
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+
+			value = expandDotenvValue(value, values)
+		}
+
+		values[name] = value
+	}
+
+	return scanner.Err()
+}
+
+func expandDotenvValue(value string, values map[string]string) string {
+	return os.Expand(value, func(name string) string {
+		if v, ok := os.LookupEnv(name); ok {
+			return v
+		}
+
+		return values[name]
+	})
+}
@@ -0,0 +1,59 @@
+package tiny
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	drainPeriod      time.Duration
+	drainPeriodMutex sync.RWMutex
+)
+
+// Drainable is an optional interface implemented by services that are able to stop accepting new work,
+// while letting the work in progress finish. For example, an HTTP server stops accepting new connections.
+type Drainable interface {
+	// Drain is expected to stop accepting new work and return without waiting for the work in progress.
+	Drain()
+}
+
+// Drain tells all the running services implementing Drainable to stop accepting new work.
+// Services are not stopped - their Stop functions are still called when StartAndBlock or Run exits.
+func Drain() {
+	for _, service := range registry.active() {
+		drainable, ok := baseService(service).(Drainable)
+		if !ok {
+			continue
+		}
+
+		log.Debug().Msgf("Draining %s", serviceName(service))
+		drainable.Drain()
+	}
+}
+
+// SetDrainPeriod enables a pre-stop phase for StartAndBlock and Run. When the process receives a shutdown signal,
+// Drain is called and the services are stopped only after the given period passes,
+// which gives load balancers time to notice the instance is going away. Zero period disables the phase (default).
+func SetDrainPeriod(period time.Duration) {
+	drainPeriodMutex.Lock()
+	defer drainPeriodMutex.Unlock()
+
+	drainPeriod = period
+}
+
+func drainBeforeStop() {
+	drainPeriodMutex.RLock()
+	period := drainPeriod
+	drainPeriodMutex.RUnlock()
+
+	if period <= 0 {
+		return
+	}
+
+	log.Info().Msgf("Draining services for %v before stopping", period)
+
+	Drain()
+	time.Sleep(period)
+}
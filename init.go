@@ -1,6 +1,9 @@
 package tiny
 
-import "github.com/mkorman9/tiny/tinylog"
+import (
+	"github.com/mkorman9/tiny/tinylog"
+	"github.com/rs/zerolog/log"
+)
 
 // Config hold a configuration for Init().
 // It allows the end-user to customize core functionalities, such as global logger or locations of config files.
@@ -8,6 +11,13 @@ type Config struct {
 	// ConfigFiles specifies a list of files that should be loaded during initialization.
 	ConfigFiles []string
 
+	// RemoteConfigSources specifies a list of remote sources that should be loaded during initialization,
+	// after ConfigFiles.
+	RemoteConfigSources []ConfigSource
+
+	// SecretFiles enables loading values of NAME_FILE variables from files, see EnableSecretFiles.
+	SecretFiles bool
+
 	// Log specifies an optional configuration for the global logger.
 	Log *tinylog.Config
 }
@@ -19,6 +29,21 @@ func Init(config ...*Config) {
 		c = config[0]
 	}
 
+	if c.SecretFiles {
+		EnableSecretFiles()
+	}
+
 	LoadConfig(c.ConfigFiles...)
+
+	// remote configuration is loaded before setting up the logger, so it can provide logging settings
+	var remoteConfigErr error
+	if c.RemoteConfigSources != nil {
+		remoteConfigErr = LoadRemoteConfig(c.RemoteConfigSources...)
+	}
+
 	tinylog.SetupLogger(c.Log)
+
+	if remoteConfigErr != nil {
+		log.Warn().Err(remoteConfigErr).Msg("Failed to load remote configuration")
+	}
 }
@@ -0,0 +1,81 @@
+package tiny
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// LifecycleEventType is a type of LifecycleEvent.
+type LifecycleEventType = string
+
+const (
+	// ServiceStartedEvent is emitted when the service becomes ready.
+	ServiceStartedEvent LifecycleEventType = "started"
+
+	// ServiceStoppedEvent is emitted when the service stops.
+	ServiceStoppedEvent LifecycleEventType = "stopped"
+
+	// ServiceFailedEvent is emitted when Start function of the service returns an error or panics.
+	ServiceFailedEvent LifecycleEventType = "failed"
+
+	// ServiceRestartedEvent is emitted when the service is restarted after a panic (see WithPanicPolicy).
+	ServiceRestartedEvent LifecycleEventType = "restarted"
+)
+
+// LifecycleEvent describes a change in the lifecycle of a service managed by StartAndBlock, Run or ServiceGroup.
+type LifecycleEvent struct {
+	// Type is a type of the event.
+	Type LifecycleEventType
+
+	// Service is a name of the service, as reported by Services.
+	Service string
+
+	// Time is a time the event occurred.
+	Time time.Time
+
+	// Err is an error that caused the service to fail, if any.
+	Err error
+}
+
+// LifecycleEventHandler is a function receiving lifecycle events.
+type LifecycleEventHandler = func(event *LifecycleEvent)
+
+var (
+	lifecycleEventHandlers      []LifecycleEventHandler
+	lifecycleEventHandlersMutex sync.RWMutex
+)
+
+// OnLifecycleEvent registers a handler receiving lifecycle events of all the managed services.
+// Handlers are called synchronously, so they should not block.
+func OnLifecycleEvent(handler LifecycleEventHandler) {
+	lifecycleEventHandlersMutex.Lock()
+	defer lifecycleEventHandlersMutex.Unlock()
+
+	lifecycleEventHandlers = append(lifecycleEventHandlers, handler)
+}
+
+func emitLifecycleEvent(event *LifecycleEvent) {
+	lifecycleEventHandlersMutex.RLock()
+	handlers := lifecycleEventHandlers
+	lifecycleEventHandlersMutex.RUnlock()
+
+	for _, handler := range handlers {
+		callLifecycleEventHandler(handler, event)
+	}
+}
+
+func callLifecycleEventHandler(handler LifecycleEventHandler, event *LifecycleEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().
+				Stack().
+				Err(fmt.Errorf("%v", r)).
+				Msg("Panic in lifecycle event handler")
+		}
+	}()
+
+	handler(event)
+}
@@ -0,0 +1,164 @@
+package tiny
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	lastReport      []ServiceReport
+	lastReportMutex sync.Mutex
+)
+
+// ServiceReport summarizes the lifecycle of a service declared with Named.
+type ServiceReport struct {
+	// Name is a name of the service.
+	Name string
+
+	// StartDuration is a time between calling Start and the service becoming ready.
+	// For services not implementing ReadinessNotifier it's close to zero.
+	StartDuration time.Duration
+
+	// StopDuration is a time it took the Stop function to return.
+	StopDuration time.Duration
+
+	// Err is an error returned by Start, if any.
+	Err error
+}
+
+type namedService struct {
+	Service
+
+	name      string
+	startedAt time.Time
+	report    ServiceReport
+	mutex     sync.Mutex
+}
+
+// Named assigns a name to the service. StartAndBlock and Run log structured events for named services,
+// including the time it took to start and stop them, and summarize them after shutdown (see ShutdownReport).
+func Named(name string, service Service) Service {
+	return &namedService{
+		Service: service,
+		name:    name,
+	}
+}
+
+// ShutdownReport returns reports of the named services managed by the most recent call to StartAndBlock or Run,
+// in the order they were started. It returns nil until the services are stopped.
+func ShutdownReport() []ServiceReport {
+	lastReportMutex.Lock()
+	defer lastReportMutex.Unlock()
+
+	return append([]ServiceReport(nil), lastReport...)
+}
+
+func (n *namedService) unwrap() Service {
+	return n.Service
+}
+
+func (n *namedService) onStart() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.startedAt = time.Now()
+	n.report = ServiceReport{Name: n.name}
+
+	log.Debug().Str("service", n.name).Msg("Starting service")
+}
+
+func (n *namedService) onReady() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.report.StartDuration = time.Since(n.startedAt)
+
+	log.Info().
+		Str("service", n.name).
+		Dur("duration", n.report.StartDuration).
+		Msg("Service started")
+}
+
+func (n *namedService) onError(err error) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.report.Err = err
+
+	log.Error().
+		Err(err).
+		Str("service", n.name).
+		Msg("Service failed")
+
+	return fmt.Errorf("%s: %w", n.name, err)
+}
+
+func (n *namedService) onStop(duration time.Duration) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.report.StopDuration = duration
+
+	log.Info().
+		Str("service", n.name).
+		Dur("duration", duration).
+		Msg("Service stopped")
+}
+
+func (n *namedService) snapshot() ServiceReport {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return n.report
+}
+
+func findNamedService(service Service) (*namedService, bool) {
+	for {
+		if named, ok := service.(*namedService); ok {
+			return named, true
+		}
+
+		wrapper, ok := service.(serviceWrapper)
+		if !ok {
+			return nil, false
+		}
+
+		service = wrapper.unwrap()
+	}
+}
+
+// saveShutdownReport stores and logs the reports of named services from given levels.
+func saveShutdownReport(levels [][]Service) {
+	var reports []ServiceReport
+	for _, level := range levels {
+		for _, service := range level {
+			if named, ok := findNamedService(service); ok {
+				reports = append(reports, named.snapshot())
+			}
+		}
+	}
+
+	if reports == nil {
+		return
+	}
+
+	lastReportMutex.Lock()
+	lastReport = reports
+	lastReportMutex.Unlock()
+
+	for _, report := range reports {
+		event := log.Info()
+		if report.Err != nil {
+			event = log.Warn().Err(report.Err)
+		}
+
+		event.
+			Str("service", report.Name).
+			Dur("start", report.StartDuration).
+			Dur("stop", report.StopDuration).
+			Msg("Service summary")
+	}
+}
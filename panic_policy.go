@@ -0,0 +1,154 @@
+package tiny
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// PanicPolicy decides what happens when the Start function of a service panics.
+type PanicPolicy int
+
+const (
+	// PanicFailFast treats the panic as an error of the service, which unblocks StartAndBlock
+	// and stops all the services. This is the default policy.
+	PanicFailFast PanicPolicy = iota
+
+	// PanicRestart restarts the service after a delay, keeping other services running.
+	PanicRestart
+
+	// PanicIsolate marks the service as failed, keeping other services running.
+	PanicIsolate
+)
+
+// PanicPolicyConfig holds a configuration for WithPanicPolicy.
+type PanicPolicyConfig struct {
+	// RestartDelay is a time to wait before restarting a service with PanicRestart policy (default: 1s).
+	RestartDelay time.Duration
+
+	// MaxRestarts is a maximal number of restarts, after which the panic is treated according to PanicFailFast.
+	// Zero value means no limit.
+	MaxRestarts int
+}
+
+type panicPolicyService struct {
+	Service
+
+	policy      PanicPolicy
+	config      *PanicPolicyConfig
+	restarts    int
+	stopChannel chan struct{}
+	stopOnce    sync.Once
+}
+
+// WithPanicPolicy sets the policy applied when the Start function of the service panics.
+// The returned Service should be passed to StartAndBlock in place of the original one.
+func WithPanicPolicy(service Service, policy PanicPolicy, config ...*PanicPolicyConfig) Service {
+	var providedConfig *PanicPolicyConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+
+	return &panicPolicyService{
+		Service:     service,
+		policy:      policy,
+		config:      mergePanicPolicyConfig(providedConfig),
+		stopChannel: make(chan struct{}),
+	}
+}
+
+func (p *panicPolicyService) unwrap() Service {
+	return p.Service
+}
+
+// Stop implements the interface of Service.
+func (p *panicPolicyService) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopChannel)
+	})
+	p.Service.Stop()
+}
+
+func (p *panicPolicyService) isStopped() bool {
+	select {
+	case <-p.stopChannel:
+		return true
+	default:
+		return false
+	}
+}
+
+// handlePanic decides whether the panicked service should be restarted (returns true),
+// or whether the panic should be reported as an error (returns false and the error).
+// The restart is abandoned if the service is stopped while waiting for it.
+func (p *panicPolicyService) handlePanic(err error) (bool, error) {
+	switch p.policy {
+	case PanicRestart:
+		if p.config.MaxRestarts > 0 && p.restarts >= p.config.MaxRestarts {
+			return false, fmt.Errorf("restart limit exceeded: %w", err)
+		}
+
+		p.restarts++
+		log.Warn().Err(err).Msgf("Service panicked, restarting in %v", p.config.RestartDelay)
+
+		select {
+		case <-time.After(p.config.RestartDelay):
+			return !p.isStopped(), nil
+		case <-p.stopChannel:
+			return false, nil
+		}
+	case PanicIsolate:
+		log.Error().Err(err).Msg("Service panicked, isolating it")
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func findPanicPolicy(service Service) (*panicPolicyService, bool) {
+	for {
+		if policy, ok := service.(*panicPolicyService); ok {
+			return policy, true
+		}
+
+		wrapper, ok := service.(serviceWrapper)
+		if !ok {
+			return nil, false
+		}
+
+		service = wrapper.unwrap()
+	}
+}
+
+// runService calls Start function of the service, recovering from a panic.
+func runService(service Service) (panicked bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	return false, service.Start()
+}
+
+func mergePanicPolicyConfig(provided *PanicPolicyConfig) *PanicPolicyConfig {
+	config := &PanicPolicyConfig{
+		RestartDelay: time.Second,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.RestartDelay > 0 {
+		config.RestartDelay = provided.RestartDelay
+	}
+	if provided.MaxRestarts > 0 {
+		config.MaxRestarts = provided.MaxRestarts
+	}
+
+	return config
+}
@@ -0,0 +1,222 @@
+package tiny
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ServiceState is a state of the service managed by StartAndBlock, Run or ServiceGroup.
+type ServiceState = string
+
+const (
+	// ServiceStarting means that Start has been called, but the service is not ready yet.
+	ServiceStarting ServiceState = "starting"
+
+	// ServiceRunning means that the service is ready and running.
+	ServiceRunning ServiceState = "running"
+
+	// ServiceStopping means that Stop has been called, but it has not returned yet.
+	ServiceStopping ServiceState = "stopping"
+
+	// ServiceFailed means that Start has returned an error or panicked.
+	ServiceFailed ServiceState = "failed"
+
+	// ServiceStopped means that the service has been stopped.
+	ServiceStopped ServiceState = "stopped"
+)
+
+// ServiceStatus describes the current state of a managed service.
+type ServiceStatus struct {
+	// Name is a name of the service, as declared with Named or exposed by the service, like the name
+	// of ServiceGroup (default: type of the service).
+	Name string
+
+	// State is a current state of the service.
+	State ServiceState
+
+	// StartedAt is a time Start has been called.
+	StartedAt time.Time
+
+	// ReadyAt is a time the service became ready (zero if it has not become ready yet).
+	ReadyAt time.Time
+
+	// StoppedAt is a time the service stopped or failed (zero if it's still running).
+	StoppedAt time.Time
+
+	// Err is an error returned by Start, if the service failed.
+	Err error
+
+	// Restarts is a number of times the service has been restarted after a panic.
+	Restarts int
+}
+
+var registry = &serviceRegistry{
+	statuses: map[Service]*ServiceStatus{},
+}
+
+type serviceRegistry struct {
+	statuses map[Service]*ServiceStatus
+	order    []Service
+	mutex    sync.RWMutex
+}
+
+// Services returns statuses of all the services started by StartAndBlock, Run or ServiceGroup,
+// in the order they were started.
+func Services() []ServiceStatus {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	statuses := make([]ServiceStatus, 0, len(registry.order))
+	for _, key := range registry.order {
+		statuses = append(statuses, *registry.statuses[key])
+	}
+
+	return statuses
+}
+
+// active returns the services that are starting or running.
+func (r *serviceRegistry) active() []Service {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var services []Service
+	for _, key := range r.order {
+		state := r.statuses[key].State
+		if state == ServiceStarting || state == ServiceRunning {
+			services = append(services, key)
+		}
+	}
+
+	return services
+}
+
+func (r *serviceRegistry) starting(service Service) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := serviceKey(service)
+	if _, exists := r.statuses[key]; !exists {
+		r.order = append(r.order, key)
+	}
+
+	r.statuses[key] = &ServiceStatus{
+		Name:      serviceName(service),
+		State:     ServiceStarting,
+		StartedAt: time.Now(),
+	}
+}
+
+// update modifies the status of the service. The update function returns the type of the lifecycle event
+// caused by the modification, or an empty string if there's none.
+func (r *serviceRegistry) update(service Service, update func(status *ServiceStatus) LifecycleEventType) {
+	r.mutex.Lock()
+
+	status, ok := r.statuses[serviceKey(service)]
+	if !ok {
+		r.mutex.Unlock()
+		return
+	}
+
+	eventType := update(status)
+	event := &LifecycleEvent{
+		Type:    eventType,
+		Service: status.Name,
+		Time:    time.Now(),
+		Err:     status.Err,
+	}
+
+	r.mutex.Unlock()
+
+	if eventType != "" {
+		emitLifecycleEvent(event)
+	}
+}
+
+func (r *serviceRegistry) running(service Service) {
+	r.update(service, func(status *ServiceStatus) LifecycleEventType {
+		if status.State != ServiceStarting {
+			return ""
+		}
+
+		status.State = ServiceRunning
+		status.ReadyAt = time.Now()
+		return ServiceStartedEvent
+	})
+}
+
+func (r *serviceRegistry) exited(service Service, err error) {
+	r.update(service, func(status *ServiceStatus) LifecycleEventType {
+		if err != nil {
+			status.State = ServiceFailed
+			status.Err = err
+			status.StoppedAt = time.Now()
+			return ServiceFailedEvent
+		}
+
+		if status.State == ServiceStarting || status.State == ServiceRunning {
+			status.State = ServiceStopped
+			status.StoppedAt = time.Now()
+			return ServiceStoppedEvent
+		}
+
+		return ""
+	})
+}
+
+func (r *serviceRegistry) restarted(service Service) {
+	r.update(service, func(status *ServiceStatus) LifecycleEventType {
+		status.Restarts++
+		return ServiceRestartedEvent
+	})
+}
+
+func (r *serviceRegistry) isolated(service Service, err error) {
+	r.update(service, func(status *ServiceStatus) LifecycleEventType {
+		status.State = ServiceFailed
+		status.Err = err
+		status.StoppedAt = time.Now()
+		return ServiceFailedEvent
+	})
+}
+
+func (r *serviceRegistry) stopping(service Service) {
+	r.update(service, func(status *ServiceStatus) LifecycleEventType {
+		if status.State != ServiceFailed && status.State != ServiceStopped {
+			status.State = ServiceStopping
+		}
+
+		return ""
+	})
+}
+
+func (r *serviceRegistry) stopped(service Service) {
+	r.update(service, func(status *ServiceStatus) LifecycleEventType {
+		if status.State != ServiceStopping {
+			return ""
+		}
+
+		status.State = ServiceStopped
+		status.StoppedAt = time.Now()
+		return ServiceStoppedEvent
+	})
+}
+
+// namer is implemented by services exposing their own name, such as ServiceGroup.
+type namer interface {
+	Name() string
+}
+
+// serviceName returns the name declared with Named, the name exposed by the service itself, or the type of the service.
+func serviceName(service Service) string {
+	if named, ok := findNamedService(service); ok {
+		return named.name
+	}
+
+	base := baseService(service)
+	if n, ok := base.(namer); ok {
+		return n.Name()
+	}
+
+	return fmt.Sprintf("%T", base)
+}
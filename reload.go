@@ -0,0 +1,71 @@
+package tiny
+
+import (
+	"os"
+	"sync"
+	"syscall"
+
+	"github.com/gookit/config/v2"
+	"github.com/mkorman9/tiny/tinylog"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	reloadCallbacks      []func()
+	reloadCallbacksMutex sync.Mutex
+	reloadMutex          sync.Mutex
+)
+
+// OnReload registers a callback invoked each time the application is reloaded with Reload,
+// for example after receiving SIGHUP from NewReloadListener.
+func OnReload(callback func()) {
+	reloadCallbacksMutex.Lock()
+	defer reloadCallbacksMutex.Unlock()
+
+	reloadCallbacks = append(reloadCallbacks, callback)
+}
+
+// Reload performs a coordinated reload of the application. It re-reads configuration files loaded by LoadConfig,
+// applies the log level from "log.level" key (if set) and invokes callbacks registered with OnReload.
+// If the configuration fails to reload, the previous one is retained and the callbacks are not invoked.
+func Reload() bool {
+	reloadMutex.Lock()
+	defer reloadMutex.Unlock()
+
+	if !ReloadConfig() {
+		return false
+	}
+
+	if level := config.String("log.level"); level != "" {
+		if err := tinylog.SetLevel(level); err != nil {
+			log.Warn().Err(err).Msgf("Invalid log level: %s", level)
+		}
+	}
+
+	reloadCallbacksMutex.Lock()
+	callbacks := reloadCallbacks
+	reloadCallbacksMutex.Unlock()
+
+	for _, callback := range callbacks {
+		callback()
+	}
+
+	log.Info().Msg("Application reloaded")
+	return true
+}
+
+// NewReloadListener creates a SignalsListener that calls Reload each time any of the given signals is received
+// (default: SIGHUP).
+func NewReloadListener(signals ...os.Signal) *SignalsListener {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+
+	return NewSignalsListener(
+		func(signal os.Signal) {
+			log.Info().Msgf("Received %v, reloading", signal)
+			Reload()
+		},
+		signals...,
+	)
+}
@@ -0,0 +1,37 @@
+package tiny
+
+import (
+	"github.com/gookit/config/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReload(t *testing.T) {
+	// given
+	file := filepath.Join(t.TempDir(), "config.yml")
+	_ = os.WriteFile(file, []byte("log:\n  level: info\n"), 0644)
+
+	LoadConfig(file)
+	defer config.ClearAll()
+
+	originalLevel := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(originalLevel)
+
+	var hookCalled bool
+	OnReload(func() {
+		hookCalled = true
+	})
+
+	_ = os.WriteFile(file, []byte("log:\n  level: warn\n"), 0644)
+
+	// when
+	reloaded := Reload()
+
+	// then
+	assert.True(t, reloaded, "application should be reloaded")
+	assert.True(t, hookCalled, "reload hook should be called")
+	assert.Equal(t, zerolog.WarnLevel, zerolog.GlobalLevel(), "log level should be updated")
+}
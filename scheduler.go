@@ -0,0 +1,271 @@
+package tiny
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrJobExists is returned when a job with the same name is already registered in the Scheduler.
+var ErrJobExists = errors.New("job with this name already exists")
+
+// ScheduledJobFunc is a function executed by the Scheduler.
+type ScheduledJobFunc = func() error
+
+// SchedulerConfig holds a configuration for NewScheduler.
+type SchedulerConfig struct {
+	// Location is a time zone used to interpret cron expressions (default: time.Local).
+	Location *time.Location
+}
+
+// JobMetrics holds statistics of a single job registered in the Scheduler.
+type JobMetrics struct {
+	// Name is a name of the job.
+	Name string
+
+	// Runs is a number of times the job has been executed.
+	Runs uint64
+
+	// Failures is a number of executions that returned an error or panicked.
+	Failures uint64
+
+	// Skipped is a number of executions skipped, because the previous one was still running.
+	Skipped uint64
+
+	// LastRun is a time of the last execution.
+	LastRun time.Time
+
+	// LastDuration is a duration of the last execution.
+	LastDuration time.Duration
+
+	// LastError is an error returned by the last execution, if any.
+	LastError error
+
+	// NextRun is a time of the next scheduled execution.
+	NextRun time.Time
+}
+
+// Scheduler is a Service executing registered jobs periodically, according to cron expressions or fixed intervals.
+// Panics in jobs are recovered, and a job is never executed concurrently with itself - if the previous execution
+// is still running when the next one is due, the latter is skipped.
+type Scheduler struct {
+	config      *SchedulerConfig
+	jobs        map[string]*scheduledJob
+	jobsLock    sync.RWMutex
+	running     bool
+	wg          sync.WaitGroup
+	stopChannel chan struct{}
+	stopOnce    sync.Once
+}
+
+type scheduledJob struct {
+	name      string
+	schedule  cron.Schedule
+	job       ScheduledJobFunc
+	executing atomic.Bool
+	metrics   JobMetrics
+	mutex     sync.Mutex
+}
+
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+func (s *intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+// NewScheduler creates new Scheduler.
+func NewScheduler(config ...*SchedulerConfig) *Scheduler {
+	var providedConfig *SchedulerConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeSchedulerConfig(providedConfig)
+
+	return &Scheduler{
+		config:      c,
+		jobs:        map[string]*scheduledJob{},
+		stopChannel: make(chan struct{}),
+	}
+}
+
+// Cron registers a job executed according to the given cron expression. Standard 5-field expressions
+// are supported, as well as descriptors such as "@hourly" or "@every 10m".
+func (s *Scheduler) Cron(name, expression string, job ScheduledJobFunc) error {
+	parsed, err := cron.ParseStandard(expression)
+	if err != nil {
+		return fmt.Errorf("invalid cron expression for job %s: %w", name, err)
+	}
+
+	if spec, ok := parsed.(*cron.SpecSchedule); ok {
+		spec.Location = s.config.Location
+	}
+
+	return s.register(name, parsed, job)
+}
+
+// Every registers a job executed periodically with given interval. The first execution happens after the interval.
+func (s *Scheduler) Every(name string, interval time.Duration, job ScheduledJobFunc) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid interval for job %s: %v", name, interval)
+	}
+
+	return s.register(name, &intervalSchedule{interval: interval}, job)
+}
+
+// Metrics returns statistics of all the registered jobs.
+func (s *Scheduler) Metrics() []JobMetrics {
+	s.jobsLock.RLock()
+	defer s.jobsLock.RUnlock()
+
+	metrics := make([]JobMetrics, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		job.mutex.Lock()
+		metrics = append(metrics, job.metrics)
+		job.mutex.Unlock()
+	}
+
+	return metrics
+}
+
+// Start implements the interface of Service.
+func (s *Scheduler) Start() error {
+	s.jobsLock.Lock()
+	s.running = true
+	for _, job := range s.jobs {
+		s.spawn(job)
+	}
+	s.jobsLock.Unlock()
+
+	<-s.stopChannel
+	return nil
+}
+
+// Stop implements the interface of Service.
+// Stop waits for the running executions to finish.
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChannel)
+	})
+
+	s.wg.Wait()
+}
+
+func (s *Scheduler) register(name string, schedule cron.Schedule, job ScheduledJobFunc) error {
+	s.jobsLock.Lock()
+	defer s.jobsLock.Unlock()
+
+	if _, exists := s.jobs[name]; exists {
+		return fmt.Errorf("%w: %s", ErrJobExists, name)
+	}
+
+	j := &scheduledJob{
+		name:     name,
+		schedule: schedule,
+		job:      job,
+		metrics:  JobMetrics{Name: name},
+	}
+	s.jobs[name] = j
+
+	if s.running {
+		s.spawn(j)
+	}
+
+	return nil
+}
+
+func (s *Scheduler) spawn(job *scheduledJob) {
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		for {
+			next := job.schedule.Next(time.Now())
+			if next.IsZero() {
+				return
+			}
+
+			job.mutex.Lock()
+			job.metrics.NextRun = next
+			job.mutex.Unlock()
+
+			timer := time.NewTimer(time.Until(next))
+
+			select {
+			case <-s.stopChannel:
+				timer.Stop()
+				return
+			case <-timer.C:
+				if !job.executing.CompareAndSwap(false, true) {
+					job.mutex.Lock()
+					job.metrics.Skipped++
+					job.mutex.Unlock()
+
+					log.Warn().Msgf("Skipping job %s, previous execution is still running", job.name)
+					continue
+				}
+
+				s.wg.Add(1)
+				go func() {
+					defer s.wg.Done()
+					defer job.executing.Store(false)
+
+					job.execute()
+				}()
+			}
+		}
+	}()
+}
+
+func (j *scheduledJob) execute() {
+	startedAt := time.Now()
+
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+
+		return j.job()
+	}()
+
+	duration := time.Since(startedAt)
+
+	j.mutex.Lock()
+	j.metrics.Runs++
+	j.metrics.LastRun = startedAt
+	j.metrics.LastDuration = duration
+	j.metrics.LastError = err
+	if err != nil {
+		j.metrics.Failures++
+	}
+	j.mutex.Unlock()
+
+	if err != nil {
+		log.Error().Err(err).Msgf("Job %s failed", j.name)
+	}
+}
+
+func mergeSchedulerConfig(provided *SchedulerConfig) *SchedulerConfig {
+	config := &SchedulerConfig{
+		Location: time.Local,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.Location != nil {
+		config.Location = provided.Location
+	}
+
+	return config
+}
@@ -0,0 +1,43 @@
+package tiny
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSchedulerEvery(t *testing.T) {
+	// given
+	scheduler := NewScheduler()
+	executed := make(chan struct{}, 10)
+
+	_ = scheduler.Every("failing", 10*time.Millisecond, func() error {
+		executed <- struct{}{}
+		return errors.New("job failed")
+	})
+
+	// when
+	go func() {
+		_ = scheduler.Start()
+	}()
+	<-executed
+	scheduler.Stop()
+
+	// then
+	metrics := scheduler.Metrics()
+	assert.Len(t, metrics, 1, "metrics should contain the job")
+	assert.GreaterOrEqual(t, metrics[0].Runs, uint64(1), "job should be executed")
+	assert.Equal(t, metrics[0].Runs, metrics[0].Failures, "all executions should fail")
+}
+
+func TestSchedulerInvalidCron(t *testing.T) {
+	// given
+	scheduler := NewScheduler()
+
+	// when
+	err := scheduler.Cron("invalid", "not a cron", func() error { return nil })
+
+	// then
+	assert.NotNil(t, err, "invalid expression should be rejected")
+}
@@ -1,12 +1,15 @@
 package tiny
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/rs/zerolog/log"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 )
 
 var shutdownSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
@@ -22,75 +25,292 @@ type Service interface {
 	Stop()
 }
 
+// ReadinessNotifier is an optional interface implemented by services that need some time to become usable after
+// their Start function is called, for example servers binding to a port.
+// Services depending on a ReadinessNotifier are not started until the returned channel is closed.
+// Services not implementing ReadinessNotifier are considered ready as soon as their Start function is called.
+type ReadinessNotifier interface {
+	// Ready returns a channel that is closed when the service is ready.
+	Ready() <-chan struct{}
+}
+
+// SignalError is returned by Run when it is unblocked by one of the shutdown signals.
+type SignalError struct {
+	// Signal is the received signal.
+	Signal os.Signal
+}
+
+func (e *SignalError) Error() string {
+	return fmt.Sprintf("received signal: %v", e.Signal)
+}
+
 // StartAndBlock starts all passed services in their designated goroutines and then blocks the current thread.
+// Services declared with DependsOn are started only after all their dependencies are ready, and stopped before
+// them. Dependencies are started even if they're not passed to StartAndBlock directly.
 // Thread is unblocked when the process receives SIGINT or SIGTERM signals or one of the Start() functions returns an error.
+// A panic in Start() is treated as an error, unless a different policy is set with WithPanicPolicy.
 // When exiting, StartAndBlock gracefully stops all the services by calling their Stop() functions and waiting for them to exit.
 func StartAndBlock(services ...Service) {
-	errorChannel := make(chan error)
+	_ = Run(context.Background(), services...)
+}
+
+// Run behaves like StartAndBlock, but it is additionally unblocked when the given context is done, and it returns
+// the cause of termination: the error returned by one of the services, *SignalError when a shutdown signal
+// has been received, or nil when the context is done. Run returns only after all the services are stopped.
+func Run(ctx context.Context, services ...Service) error {
+	levels, err := resolveStartupOrder(services)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to resolve the order of services")
+		return err
+	}
+
+	shutdownSignalsChannel := make(chan os.Signal, 1)
+	signal.Notify(shutdownSignalsChannel, shutdownSignals...)
+	defer signal.Stop(shutdownSignalsChannel)
+
+	errorChannel := make(chan error, countServices(levels))
+
+	var startedLevels [][]Service
+	defer func() {
+		for i := len(startedLevels) - 1; i >= 0; i-- {
+			stopServices(startedLevels[i])
+		}
+
+		saveShutdownReport(startedLevels)
+	}()
+
+	for i, level := range levels {
+		startedLevels = append(startedLevels, level)
+
+		for _, service := range level {
+			startService(service, errorChannel)
+		}
+
+		if i < len(levels)-1 {
+			for _, service := range level {
+				if ready, err := waitUntilReady(ctx, service, errorChannel, shutdownSignalsChannel); !ready {
+					return err
+				}
+			}
+		}
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	notifyWhenReady(levels, done)
+
+	err = blockThread(ctx, errorChannel, shutdownSignalsChannel)
+
+	var signalErr *SignalError
+	if errors.As(err, &signalErr) {
+		drainBeforeStop()
+	}
+
+	return err
+}
+
+func startService(service Service, errorChannel chan<- error) {
+	registry.starting(service)
+
+	named, isNamed := findNamedService(service)
+	if isNamed {
+		named.onStart()
+	}
+
+	onReady := func() {
+		registry.running(service)
+		if isNamed {
+			named.onReady()
+		}
+	}
+
+	if notifier, ok := findReadinessNotifier(service); ok {
+		go func() {
+			<-notifier.Ready()
+			onReady()
+		}()
+	} else {
+		onReady()
+	}
+
+	onExit := func(err error) {
+		registry.exited(service, err)
+		if err == nil {
+			return
+		}
+
+		if isNamed {
+			err = named.onError(err)
+		}
+
+		select {
+		case errorChannel <- err:
+		default:
+		}
+	}
+
+	go func() {
+		for {
+			panicked, err := runService(service)
+			if !panicked {
+				onExit(err)
+				return
+			}
+
+			policy, ok := findPanicPolicy(service)
+			if !ok {
+				onExit(err)
+				return
+			}
+
+			restart, fatalErr := policy.handlePanic(err)
+			if !restart {
+				switch {
+				case fatalErr != nil:
+					onExit(fatalErr)
+				case policy.isStopped():
+					onExit(nil)
+				default:
+					registry.isolated(service, err)
+				}
+
+				return
+			}
+
+			registry.restarted(service)
+		}
+	}()
+}
+
+func stopServices(services []Service) {
+	wg := &sync.WaitGroup{}
+	wg.Add(len(services))
 
 	for _, service := range services {
 		s := service
 
 		go func() {
+			startedAt := time.Now()
+			registry.stopping(s)
+
 			defer func() {
 				if r := recover(); r != nil {
-					select {
-					case errorChannel <- fmt.Errorf("%v", r):
-					default:
-					}
+					log.Error().
+						Stack().
+						Err(fmt.Errorf("%v", r)).
+						Msg("Panic while stopping service")
 				}
-			}()
 
-			if err := s.Start(); err != nil {
-				select {
-				case errorChannel <- err:
-				default:
+				registry.stopped(s)
+				if named, ok := findNamedService(s); ok {
+					named.onStop(time.Since(startedAt))
 				}
-			}
+
+				wg.Done()
+			}()
+
+			s.Stop()
 		}()
 	}
 
-	defer func() {
-		wg := &sync.WaitGroup{}
-		wg.Add(len(services))
+	wg.Wait()
+}
 
-		for _, service := range services {
-			s := service
+// servicesReadyObserver is implemented by services that need to know when all the other services managed
+// by the same StartAndBlock, Run or ServiceGroup are ready, such as SystemdNotifier.
+type servicesReadyObserver interface {
+	servicesReady()
+}
 
-			go func() {
-				defer func() {
-					if r := recover(); r != nil {
-						log.Error().
-							Stack().
-							Err(fmt.Errorf("%v", r)).
-							Msg("Panic while stopping service")
-					}
+func findServicesReadyObserver(service Service) (servicesReadyObserver, bool) {
+	for {
+		if observer, ok := service.(servicesReadyObserver); ok {
+			return observer, true
+		}
 
-					wg.Done()
-				}()
+		wrapper, ok := service.(serviceWrapper)
+		if !ok {
+			return nil, false
+		}
 
-				s.Stop()
-			}()
+		service = wrapper.unwrap()
+	}
+}
+
+// notifyWhenReady notifies the observers from given levels once all the services from these levels are ready.
+// It should be called after all the services are started. It gives up when the done channel is closed.
+func notifyWhenReady(levels [][]Service, done <-chan struct{}) {
+	var observers []servicesReadyObserver
+	for _, level := range levels {
+		for _, service := range level {
+			if observer, ok := findServicesReadyObserver(service); ok {
+				observers = append(observers, observer)
+			}
 		}
+	}
 
-		wg.Wait()
-	}()
+	if len(observers) == 0 {
+		return
+	}
 
-	blockThread(errorChannel)
-}
+	go func() {
+		for _, level := range levels {
+			for _, service := range level {
+				notifier, ok := findReadinessNotifier(service)
+				if !ok {
+					continue
+				}
 
-func blockThread(errorChannel <-chan error) {
-	shutdownSignalsChannel := make(chan os.Signal)
-	signal.Notify(shutdownSignalsChannel, shutdownSignals...)
+				select {
+				case <-notifier.Ready():
+				case <-done:
+					return
+				}
+			}
+		}
 
-	for {
-		select {
-		case err := <-errorChannel:
-			log.Error().Err(err).Msg("Unblocking thread due to an error")
-			return
-		case s := <-shutdownSignalsChannel:
-			log.Info().Msgf("Unblocking thread due to a signal: %v", s)
-			return
+		for _, observer := range observers {
+			observer.servicesReady()
 		}
+	}()
+}
+
+func waitUntilReady(
+	ctx context.Context,
+	service Service,
+	errorChannel <-chan error,
+	shutdownSignalsChannel <-chan os.Signal,
+) (bool, error) {
+	notifier, ok := findReadinessNotifier(service)
+	if !ok {
+		return true, nil
+	}
+
+	select {
+	case <-notifier.Ready():
+		return true, nil
+	case err := <-errorChannel:
+		log.Error().Err(err).Msg("Unblocking thread due to an error during startup")
+		return false, err
+	case s := <-shutdownSignalsChannel:
+		log.Info().Msgf("Unblocking thread due to a signal during startup: %v", s)
+		return false, &SignalError{Signal: s}
+	case <-ctx.Done():
+		log.Info().Msg("Unblocking thread due to context cancellation during startup")
+		return false, nil
+	}
+}
+
+func blockThread(ctx context.Context, errorChannel <-chan error, shutdownSignalsChannel <-chan os.Signal) error {
+	select {
+	case err := <-errorChannel:
+		log.Error().Err(err).Msg("Unblocking thread due to an error")
+		return err
+	case s := <-shutdownSignalsChannel:
+		log.Info().Msgf("Unblocking thread due to a signal: %v", s)
+		return &SignalError{Signal: s}
+	case <-ctx.Done():
+		log.Info().Msg("Unblocking thread due to context cancellation")
+		return nil
 	}
 }
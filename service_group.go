@@ -0,0 +1,134 @@
+package tiny
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ServiceGroup is a Service composed of other services, started and stopped as a single unit.
+// Members declared with DependsOn are started in dependency order, just like in StartAndBlock.
+// ServiceGroup implements ReadinessNotifier - it becomes ready when all of its members are ready.
+type ServiceGroup struct {
+	name          string
+	services      []Service
+	startedLevels [][]Service
+	started       bool
+	stopped       bool
+	mutex         sync.Mutex
+	readyChannel  chan struct{}
+	readyOnce     sync.Once
+	stopChannel   chan struct{}
+	stopOnce      sync.Once
+}
+
+// NewServiceGroup creates new ServiceGroup with given name, composed of given services.
+func NewServiceGroup(name string, services ...Service) *ServiceGroup {
+	return &ServiceGroup{
+		name:         name,
+		services:     services,
+		readyChannel: make(chan struct{}),
+		stopChannel:  make(chan struct{}),
+	}
+}
+
+// Name returns the name of the group.
+func (g *ServiceGroup) Name() string {
+	return g.name
+}
+
+// Ready implements the interface of ReadinessNotifier.
+func (g *ServiceGroup) Ready() <-chan struct{} {
+	return g.readyChannel
+}
+
+// Start implements the interface of Service.
+// Start returns an error as soon as any of the members fails.
+func (g *ServiceGroup) Start() error {
+	g.mutex.Lock()
+	if g.started {
+		g.mutex.Unlock()
+		return fmt.Errorf("service group %s: already started", g.name)
+	}
+	g.started = true
+	g.mutex.Unlock()
+
+	levels, err := resolveStartupOrder(g.services)
+	if err != nil {
+		return fmt.Errorf("service group %s: %w", g.name, err)
+	}
+
+	errorChannel := make(chan error, countServices(levels))
+
+	for _, level := range levels {
+		if !g.startLevel(level, errorChannel) {
+			return nil
+		}
+
+		for _, service := range level {
+			notifier, ok := findReadinessNotifier(service)
+			if !ok {
+				continue
+			}
+
+			select {
+			case <-notifier.Ready():
+			case err := <-errorChannel:
+				return fmt.Errorf("service group %s: %w", g.name, err)
+			case <-g.stopChannel:
+				return nil
+			}
+		}
+	}
+
+	g.readyOnce.Do(func() {
+		close(g.readyChannel)
+	})
+	log.Info().Msgf("Service group %s started", g.name)
+
+	notifyWhenReady(levels, g.stopChannel)
+
+	select {
+	case err := <-errorChannel:
+		return fmt.Errorf("service group %s: %w", g.name, err)
+	case <-g.stopChannel:
+		return nil
+	}
+}
+
+// Stop implements the interface of Service.
+// Members are stopped in reverse dependency order.
+func (g *ServiceGroup) Stop() {
+	g.stopOnce.Do(func() {
+		g.mutex.Lock()
+		g.stopped = true
+		startedLevels := g.startedLevels
+		g.mutex.Unlock()
+
+		for i := len(startedLevels) - 1; i >= 0; i-- {
+			stopServices(startedLevels[i])
+		}
+
+		close(g.stopChannel)
+		log.Info().Msgf("Service group %s stopped", g.name)
+	})
+}
+
+// startLevel starts the members from given level, unless the group is already stopped. The level is recorded
+// under the same lock Stop takes, so the members are never stopped before being started.
+func (g *ServiceGroup) startLevel(level []Service, errorChannel chan<- error) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.stopped {
+		return false
+	}
+
+	g.startedLevels = append(g.startedLevels, level)
+	for _, service := range level {
+		startService(service, errorChannel)
+	}
+
+	return true
+}
@@ -0,0 +1,172 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type blockingService struct {
+	stopChannel chan struct{}
+	stopped     atomic.Bool
+}
+
+func newBlockingService() *blockingService {
+	return &blockingService{stopChannel: make(chan struct{})}
+}
+
+func (b *blockingService) Start() error {
+	<-b.stopChannel
+	return nil
+}
+
+func (b *blockingService) Stop() {
+	if b.stopped.CompareAndSwap(false, true) {
+		close(b.stopChannel)
+	}
+}
+
+func TestServiceGroupStartStop(t *testing.T) {
+	// given
+	first := newBlockingService()
+	second := newBlockingService()
+	group := NewServiceGroup("group", first, second)
+
+	result := make(chan error, 1)
+	go func() {
+		result <- group.Start()
+	}()
+
+	// when
+	select {
+	case <-group.Ready():
+	case <-time.After(time.Second):
+		assert.Fail(t, "group should become ready")
+	}
+	group.Stop()
+
+	// then
+	assert.NoError(t, <-result, "group should stop without an error")
+	assert.True(t, first.stopped.Load(), "first member should be stopped")
+	assert.True(t, second.stopped.Load(), "second member should be stopped")
+}
+
+func TestServiceGroupStartTwice(t *testing.T) {
+	// given
+	member := newBlockingService()
+	group := NewServiceGroup("group", member)
+
+	result := make(chan error, 1)
+	go func() {
+		result <- group.Start()
+	}()
+	<-group.Ready()
+
+	// when
+	err := group.Start()
+	group.Stop()
+
+	// then
+	assert.EqualError(t, err, "service group group: already started", "second start should be rejected")
+	assert.NoError(t, <-result, "group should stop without an error")
+}
+
+func TestServiceGroupStopBeforeStart(t *testing.T) {
+	// given
+	member := &startRecordingService{
+		blockingService: blockingService{stopChannel: make(chan struct{})},
+		startedChannel:  make(chan struct{}),
+	}
+	group := NewServiceGroup("group", member)
+
+	// when
+	group.Stop()
+	err := group.Start()
+
+	// then
+	assert.NoError(t, err, "start after stop should return immediately")
+	select {
+	case <-member.startedChannel:
+		assert.Fail(t, "member should not be started")
+	default:
+	}
+}
+
+func TestServiceGroupMemberError(t *testing.T) {
+	// given
+	expectedErr := errors.New("member failed")
+	healthy := newBlockingService()
+	group := NewServiceGroup("group", healthy, &failingService{err: expectedErr})
+
+	// when
+	err := group.Start()
+	group.Stop()
+
+	// then
+	assert.ErrorIs(t, err, expectedErr, "error of the member should be returned")
+	assert.EqualError(t, err, "service group group: member failed", "error should contain name of the group")
+	assert.True(t, healthy.stopped.Load(), "other members should be stopped")
+}
+
+func TestServiceGroupReadyWaitsForDependencies(t *testing.T) {
+	// given
+	dependency := &notReadyService{
+		blockingService: blockingService{stopChannel: make(chan struct{})},
+		readyChannel:    make(chan struct{}),
+	}
+	group := NewServiceGroup("group", DependsOn(newBlockingService(), dependency))
+
+	go func() {
+		_ = group.Start()
+	}()
+	defer group.Stop()
+
+	// when
+	var readyBefore bool
+	select {
+	case <-group.Ready():
+		readyBefore = true
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(dependency.readyChannel)
+
+	// then
+	assert.False(t, readyBefore, "group should not be ready before its members")
+	select {
+	case <-group.Ready():
+	case <-time.After(time.Second):
+		assert.Fail(t, "group should become ready after its members")
+	}
+}
+
+type notReadyService struct {
+	blockingService
+	readyChannel chan struct{}
+}
+
+func (n *notReadyService) Ready() <-chan struct{} {
+	return n.readyChannel
+}
+
+func TestServiceGroupName(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	group := NewServiceGroup("named-group", newBlockingService())
+
+	// when
+	_ = Run(ctx, group)
+
+	// then
+	var found bool
+	for _, status := range Services() {
+		if status.Name == "named-group" {
+			found = true
+		}
+	}
+	assert.True(t, found, "group should be registered under its name")
+}
@@ -0,0 +1,179 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type failingService struct {
+	err     error
+	stopped bool
+}
+
+func (f *failingService) Start() error {
+	return f.err
+}
+
+func (f *failingService) Stop() {
+	f.stopped = true
+}
+
+func TestRunReturnsServiceError(t *testing.T) {
+	// given
+	expectedErr := errors.New("startup failed")
+	service := &failingService{err: expectedErr}
+
+	// when
+	err := Run(context.Background(), service)
+
+	// then
+	assert.ErrorIs(t, err, expectedErr, "error of the service should be returned")
+	assert.True(t, service.stopped, "service should be stopped")
+}
+
+func TestRunContextCancelled(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	err := Run(ctx, &dummyService{name: "service"})
+
+	// then
+	assert.Nil(t, err, "cancellation should not be reported as an error")
+}
+
+func TestNamedServiceReport(t *testing.T) {
+	// given
+	expectedErr := errors.New("startup failed")
+	service := Named("database", &failingService{err: expectedErr})
+
+	// when
+	err := Run(context.Background(), service)
+
+	// then
+	assert.ErrorIs(t, err, expectedErr, "error of the service should be returned")
+	assert.EqualError(t, err, "database: startup failed", "error should contain service name")
+
+	report := ShutdownReport()
+	assert.Len(t, report, 1, "report should contain named service")
+	assert.Equal(t, "database", report[0].Name, "report should contain name of the service")
+	assert.ErrorIs(t, report[0].Err, expectedErr, "report should contain error of the service")
+}
+
+func TestServicesRegistry(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	service := &dummyService{name: "registered"}
+
+	// when
+	_ = Run(ctx, Named("registered", service))
+
+	// then
+	var found bool
+	for _, status := range Services() {
+		if status.Name == "registered" {
+			found = true
+			assert.Equal(t, ServiceStopped, status.State, "service should be stopped")
+			assert.False(t, status.StartedAt.IsZero(), "start time should be recorded")
+			assert.False(t, status.StoppedAt.IsZero(), "stop time should be recorded")
+		}
+	}
+	assert.True(t, found, "service should be registered")
+}
+
+type panickingService struct {
+	starts int
+}
+
+func (p *panickingService) Start() error {
+	p.starts++
+	if p.starts == 1 {
+		panic("first start failed")
+	}
+
+	return errors.New("second start failed")
+}
+
+func (p *panickingService) Stop() {
+}
+
+func TestPanicRestart(t *testing.T) {
+	// given
+	service := &panickingService{}
+
+	// when
+	err := Run(
+		context.Background(),
+		WithPanicPolicy(service, PanicRestart, &PanicPolicyConfig{RestartDelay: time.Millisecond}),
+	)
+
+	// then
+	assert.EqualError(t, err, "second start failed", "service should be restarted after panic")
+	assert.Equal(t, 2, service.starts, "service should be started twice")
+}
+
+type alwaysPanickingService struct {
+	panicked chan struct{}
+	once     sync.Once
+}
+
+func (a *alwaysPanickingService) Start() error {
+	a.once.Do(func() {
+		close(a.panicked)
+	})
+	panic("start failed")
+}
+
+func (a *alwaysPanickingService) Stop() {
+}
+
+func TestStopDuringRestartDelay(t *testing.T) {
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	service := &alwaysPanickingService{panicked: make(chan struct{})}
+	go func() {
+		<-service.panicked
+		cancel()
+	}()
+
+	// when
+	_ = Run(ctx, Named(
+		"restart-delay",
+		WithPanicPolicy(service, PanicRestart, &PanicPolicyConfig{RestartDelay: 50 * time.Millisecond}),
+	))
+	time.Sleep(100 * time.Millisecond)
+
+	// then
+	var found bool
+	for _, status := range Services() {
+		if status.Name == "restart-delay" {
+			found = true
+			assert.Equal(t, ServiceStopped, status.State, "service should be reported as stopped")
+			assert.Nil(t, status.Err, "no error should be reported")
+		}
+	}
+	assert.True(t, found, "service should be registered")
+}
+
+func TestLifecycleEvents(t *testing.T) {
+	// given
+	expectedErr := errors.New("startup failed")
+	var events []LifecycleEventType
+	OnLifecycleEvent(func(event *LifecycleEvent) {
+		if event.Service == "events" {
+			events = append(events, event.Type)
+		}
+	})
+
+	// when
+	_ = Run(context.Background(), Named("events", &failingService{err: expectedErr}))
+
+	// then
+	assert.Equal(t, []LifecycleEventType{ServiceStartedEvent, ServiceFailedEvent}, events, "events should be emitted")
+}
@@ -26,7 +26,7 @@ func NewSignalsListener(handler SignalHandler, signals ...os.Signal) *SignalsLis
 
 // Start implements the interface of Service.
 func (s *SignalsListener) Start() error {
-	signalsChannel := make(chan os.Signal)
+	signalsChannel := make(chan os.Signal, 1)
 	signal.Notify(signalsChannel, s.signals...)
 
 	for {
@@ -0,0 +1,190 @@
+package tiny
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SystemdNotifierConfig holds a configuration for NewSystemdNotifier.
+type SystemdNotifierConfig struct {
+	// WatchdogInterval is a time between subsequent WATCHDOG=1 notifications
+	// (default: half of WatchdogSec configured in the systemd unit, watchdog is disabled if it's not configured).
+	WatchdogInterval time.Duration
+}
+
+// SystemdNotifier is a Service integrating the process with systemd supervision, using the sd_notify protocol.
+// It sends READY=1 once all the other services passed to StartAndBlock, Run or ServiceGroup along with it are ready,
+// WATCHDOG=1 periodically while running, and STOPPING=1 when stopped. Services implementing ReadinessNotifier
+// (such as tinyhttp.Server and tinygrpc.Server, which become ready once bound to their addresses) are awaited,
+// while the other ones are considered ready as soon as they're started.
+//
+// When the process is not started by systemd (NOTIFY_SOCKET is not set), SystemdNotifier does nothing.
+type SystemdNotifier struct {
+	config               *SystemdNotifierConfig
+	servicesReadyChannel chan struct{}
+	servicesReadyOnce    sync.Once
+	stopChannel          chan struct{}
+	stopOnce             sync.Once
+}
+
+// NewSystemdNotifier creates new SystemdNotifier.
+func NewSystemdNotifier(config ...*SystemdNotifierConfig) *SystemdNotifier {
+	var providedConfig *SystemdNotifierConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeSystemdNotifierConfig(providedConfig)
+
+	return &SystemdNotifier{
+		config:               c,
+		servicesReadyChannel: make(chan struct{}),
+		stopChannel:          make(chan struct{}),
+	}
+}
+
+// Start implements the interface of Service.
+func (n *SystemdNotifier) Start() error {
+	select {
+	case <-n.servicesReadyChannel:
+	case <-n.stopChannel:
+		return nil
+	}
+
+	if err := SystemdNotify("READY=1"); err != nil {
+		log.Warn().Err(err).Msg("Failed to notify systemd about readiness")
+	}
+
+	if n.config.WatchdogInterval <= 0 {
+		<-n.stopChannel
+		return nil
+	}
+
+	ticker := time.NewTicker(n.config.WatchdogInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-n.stopChannel:
+			return nil
+		case <-ticker.C:
+			if err := SystemdNotify("WATCHDOG=1"); err != nil {
+				log.Warn().Err(err).Msg("Failed to send watchdog notification to systemd")
+			}
+		}
+	}
+}
+
+// Stop implements the interface of Service.
+func (n *SystemdNotifier) Stop() {
+	n.stopOnce.Do(func() {
+		if err := SystemdNotify("STOPPING=1"); err != nil {
+			log.Warn().Err(err).Msg("Failed to notify systemd about stopping")
+		}
+
+		close(n.stopChannel)
+	})
+}
+
+func (n *SystemdNotifier) servicesReady() {
+	n.servicesReadyOnce.Do(func() {
+		close(n.servicesReadyChannel)
+	})
+}
+
+// SystemdNotify sends given state (for example "READY=1" or "STATUS=...") to systemd, using the socket
+// specified by NOTIFY_SOCKET environment variable. It does nothing if the variable is not set.
+func SystemdNotify(state string) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+
+	// abstract namespace socket
+	if strings.HasPrefix(socket, "@") {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	_, err = conn.Write([]byte(state))
+	return err
+}
+
+// ErrNoSystemdSockets is returned by SystemdListener when the process has not been socket-activated by systemd.
+var ErrNoSystemdSockets = errors.New("no sockets passed by systemd")
+
+// systemdListenFDsStart is the first file descriptor passed by systemd socket activation.
+const systemdListenFDsStart = 3
+
+// SystemdListener returns a listener for the socket passed by systemd using socket activation (LISTEN_FDS).
+// If name is not empty, the socket with matching FileDescriptorName= is selected, otherwise the first one.
+func SystemdListener(name string) (net.Listener, error) {
+	if pid, err := strconv.Atoi(os.Getenv("LISTEN_PID")); err != nil || pid != os.Getpid() {
+		return nil, ErrNoSystemdSockets
+	}
+
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count <= 0 {
+		return nil, ErrNoSystemdSockets
+	}
+
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+
+	for i := 0; i < count; i++ {
+		if name != "" && (i >= len(names) || names[i] != name) {
+			continue
+		}
+
+		file := os.NewFile(uintptr(systemdListenFDsStart+i), "systemd-socket")
+		listener, err := net.FileListener(file)
+		_ = file.Close()
+
+		return listener, err
+	}
+
+	return nil, fmt.Errorf("systemd socket not found: %s", name)
+}
+
+// systemdWatchdogInterval returns half of the watchdog timeout configured by systemd, or zero if it's disabled.
+func systemdWatchdogInterval() time.Duration {
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0
+	}
+
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+
+	return time.Duration(usec) * time.Microsecond / 2
+}
+
+func mergeSystemdNotifierConfig(provided *SystemdNotifierConfig) *SystemdNotifierConfig {
+	config := &SystemdNotifierConfig{
+		WatchdogInterval: systemdWatchdogInterval(),
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.WatchdogInterval > 0 {
+		config.WatchdogInterval = provided.WatchdogInterval
+	}
+
+	return config
+}
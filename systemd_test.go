@@ -0,0 +1,73 @@
+package tiny
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSystemdNotify(t *testing.T) {
+	// given
+	socketPath := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("unixgram sockets are not supported: %v", err)
+	}
+	defer conn.Close()
+
+	t.Setenv("NOTIFY_SOCKET", socketPath)
+
+	// when
+	err = SystemdNotify("READY=1")
+
+	// then
+	assert.Nil(t, err, "notification should be sent")
+
+	buffer := make([]byte, 64)
+	n, _ := conn.Read(buffer)
+	assert.Equal(t, "READY=1", string(buffer[:n]), "state should be received")
+}
+
+func TestSystemdNotifierWaitsForServices(t *testing.T) {
+	// given
+	socketPath := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("unixgram sockets are not supported: %v", err)
+	}
+	defer conn.Close()
+
+	t.Setenv("NOTIFY_SOCKET", socketPath)
+
+	server := &notReadyService{
+		blockingService: blockingService{stopChannel: make(chan struct{})},
+		readyChannel:    make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() {
+		result <- Run(ctx, NewSystemdNotifier(), server)
+	}()
+
+	buffer := make([]byte, 64)
+
+	// when
+	_ = conn.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+	_, errBeforeReady := conn.Read(buffer)
+
+	close(server.readyChannel)
+
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, _ := conn.Read(buffer)
+
+	cancel()
+	<-result
+
+	// then
+	assert.Error(t, errBeforeReady, "READY=1 should not be sent before other services are ready")
+	assert.Equal(t, "READY=1", string(buffer[:n]), "READY=1 should be sent after other services are ready")
+}
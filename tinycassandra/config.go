@@ -0,0 +1,110 @@
+package tinycassandra
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// Config holds a configuration for Dial.
+type Config struct {
+	// Keyspace is a default keyspace for the session.
+	Keyspace string
+
+	// Username is a username for password authentication.
+	Username string
+
+	// Password is a password for password authentication.
+	Password string
+
+	// Consistency is a default consistency level of queries, for example "one", "quorum" or "local_quorum"
+	// (default: "quorum").
+	Consistency string
+
+	// Timeout is a timeout of a single query (default: 5s).
+	Timeout time.Duration
+
+	// ConnectTimeout is a timeout of establishing a connection to a single host (default: 5s).
+	ConnectTimeout time.Duration
+
+	// NumConns is a number of connections per host (default: 2).
+	NumConns int
+
+	// ReconnectInitialInterval is a delay before the first attempt to reconnect to a lost host.
+	// Each subsequent attempt doubles the delay (default: 1s).
+	ReconnectInitialInterval time.Duration
+
+	// ReconnectMaxInterval is an upper bound of the delay between attempts to reconnect (default: 1m).
+	ReconnectMaxInterval time.Duration
+
+	// ReconnectMaxRetries is a maximum number of attempts to reconnect to a lost host (default: 10).
+	ReconnectMaxRetries int
+
+	// TLSConfig is an optional TLS configuration. When specified - enables TLS mode.
+	TLSConfig *tls.Config
+
+	// Verbose specifies whether to log all executed queries.
+	Verbose bool
+
+	// GocqlOpt allows to specify a function that operates directly on *gocql.ClusterConfig.
+	GocqlOpt func(*gocql.ClusterConfig)
+}
+
+func mergeConfig(provided *Config) *Config {
+	config := &Config{
+		Consistency:              "quorum",
+		Timeout:                  5 * time.Second,
+		ConnectTimeout:           5 * time.Second,
+		NumConns:                 2,
+		ReconnectInitialInterval: time.Second,
+		ReconnectMaxInterval:     time.Minute,
+		ReconnectMaxRetries:      10,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.Keyspace != "" {
+		config.Keyspace = provided.Keyspace
+	}
+	if provided.Username != "" {
+		config.Username = provided.Username
+	}
+	if provided.Password != "" {
+		config.Password = provided.Password
+	}
+	if provided.Consistency != "" {
+		config.Consistency = provided.Consistency
+	}
+	if provided.Timeout > 0 {
+		config.Timeout = provided.Timeout
+	}
+	if provided.ConnectTimeout > 0 {
+		config.ConnectTimeout = provided.ConnectTimeout
+	}
+	if provided.NumConns > 0 {
+		config.NumConns = provided.NumConns
+	}
+	if provided.ReconnectInitialInterval > 0 {
+		config.ReconnectInitialInterval = provided.ReconnectInitialInterval
+	}
+	if provided.ReconnectMaxInterval > 0 {
+		config.ReconnectMaxInterval = provided.ReconnectMaxInterval
+	}
+	if provided.ReconnectMaxRetries > 0 {
+		config.ReconnectMaxRetries = provided.ReconnectMaxRetries
+	}
+	if provided.TLSConfig != nil {
+		config.TLSConfig = provided.TLSConfig
+	}
+	if provided.Verbose {
+		config.Verbose = true
+	}
+	if provided.GocqlOpt != nil {
+		config.GocqlOpt = provided.GocqlOpt
+	}
+
+	return config
+}
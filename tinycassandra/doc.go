@@ -0,0 +1,4 @@
+/*
+Package tinycassandra provides utilities for handling Cassandra.
+*/
+package tinycassandra
@@ -0,0 +1,37 @@
+package tinycassandra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"github.com/rs/zerolog/log"
+)
+
+type queryLogger struct {
+	verbose bool
+}
+
+func (l *queryLogger) ObserveQuery(_ context.Context, query gocql.ObservedQuery) {
+	if query.Err != nil {
+		log.Warn().Err(query.Err).Msgf("Cassandra error for: '%s'", query.Statement)
+	} else if l.verbose {
+		elapsed := query.End.Sub(query.Start)
+		log.Debug().Msgf("Cassandra query (%v) [%d rows]: '%s'", elapsed.String(), query.Rows, query.Statement)
+	}
+}
+
+type driverLogger struct {
+}
+
+func (l *driverLogger) Print(v ...interface{}) {
+	log.Debug().Msg(fmt.Sprint(v...))
+}
+
+func (l *driverLogger) Printf(format string, v ...interface{}) {
+	log.Debug().Msgf(format, v...)
+}
+
+func (l *driverLogger) Println(v ...interface{}) {
+	log.Debug().Msg(fmt.Sprint(v...))
+}
@@ -0,0 +1,105 @@
+package tinycassandra
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gocql/gocql"
+	"github.com/rs/zerolog/log"
+)
+
+// Session is an object representing gocql.Session and implementing the tiny.Service interface.
+// The session is closed when the service is stopped.
+type Session struct {
+	*gocql.Session
+
+	stopChannel chan struct{}
+}
+
+// Dial creates a session connected to Cassandra cluster available under given hosts, and returns *Session instance.
+func Dial(hosts []string, config ...*Config) (*Session, error) {
+	var providedConfig *Config
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeConfig(providedConfig)
+
+	if len(hosts) == 0 {
+		return nil, errors.New("hosts cannot be empty")
+	}
+
+	consistency, err := gocql.ParseConsistencyWrapper(c.Consistency)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := gocql.NewCluster(hosts...)
+	cluster.Keyspace = c.Keyspace
+	cluster.Consistency = consistency
+	cluster.Timeout = c.Timeout
+	cluster.ConnectTimeout = c.ConnectTimeout
+	cluster.NumConns = c.NumConns
+	cluster.ReconnectionPolicy = &gocql.ExponentialReconnectionPolicy{
+		MaxRetries:      c.ReconnectMaxRetries,
+		InitialInterval: c.ReconnectInitialInterval,
+		MaxInterval:     c.ReconnectMaxInterval,
+	}
+	cluster.QueryObserver = &queryLogger{verbose: c.Verbose}
+	cluster.Logger = &driverLogger{}
+
+	if c.Username != "" {
+		cluster.Authenticator = gocql.PasswordAuthenticator{
+			Username: c.Username,
+			Password: c.Password,
+		}
+	}
+
+	if c.TLSConfig != nil {
+		cluster.SslOpts = &gocql.SslOptions{
+			Config:                 c.TLSConfig,
+			EnableHostVerification: !c.TLSConfig.InsecureSkipVerify,
+		}
+	}
+
+	if c.GocqlOpt != nil {
+		c.GocqlOpt(cluster)
+	}
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		Session:     session,
+		stopChannel: make(chan struct{}, 1),
+	}, nil
+}
+
+// HealthCheck executes a lightweight query against the cluster and returns an error if it fails.
+func (s *Session) HealthCheck(ctx context.Context) error {
+	if s.Closed() {
+		return errors.New("session is closed")
+	}
+
+	return s.Query("SELECT now() FROM system.local").
+		WithContext(ctx).
+		Exec()
+}
+
+// Start implements the interface of tiny.Service.
+func (s *Session) Start() error {
+	<-s.stopChannel
+	return nil
+}
+
+// Stop implements the interface of tiny.Service.
+func (s *Session) Stop() {
+	s.Close()
+	log.Info().Msg("Cassandra session closed")
+
+	select {
+	case s.stopChannel <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,202 @@
+package tinyclickhouse
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/rs/zerolog/log"
+)
+
+// BatchInserter is a Service that buffers rows in memory and periodically inserts them into ClickHouse
+// in a single batch. All the buffered rows are flushed when the service is stopped.
+type BatchInserter struct {
+	conn   driver.Conn
+	query  string
+	config *BatchInserterConfig
+
+	rows         [][]any
+	rowsMutex    sync.Mutex
+	flushChannel chan struct{}
+	stopChannel  chan struct{}
+	stopOnce     sync.Once
+	doneChannel  chan struct{}
+	stateLock    sync.Mutex
+	started      bool
+	stopped      bool
+	metrics      batchInserterMetrics
+}
+
+// BatchInserterMetrics holds statistics of the BatchInserter.
+type BatchInserterMetrics struct {
+	// RowsInserted is a total number of rows successfully inserted.
+	RowsInserted uint64
+
+	// RowsFailed is a total number of rows dropped because of a failed insert.
+	RowsFailed uint64
+
+	// Batches is a total number of batches sent.
+	Batches uint64
+
+	// FailedBatches is a total number of batches that failed to be sent.
+	FailedBatches uint64
+
+	// Pending is a number of rows currently waiting in the buffer.
+	Pending int
+}
+
+type batchInserterMetrics struct {
+	rowsInserted  uint64
+	rowsFailed    uint64
+	batches       uint64
+	failedBatches uint64
+}
+
+// NewBatchInserter creates new BatchInserter executing given INSERT query, for example:
+// "INSERT INTO events (time, name, value)".
+func NewBatchInserter(conn driver.Conn, query string, config ...*BatchInserterConfig) *BatchInserter {
+	var providedConfig *BatchInserterConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeBatchInserterConfig(providedConfig)
+
+	return &BatchInserter{
+		conn:         conn,
+		query:        query,
+		config:       c,
+		flushChannel: make(chan struct{}, 1),
+		stopChannel:  make(chan struct{}),
+		doneChannel:  make(chan struct{}),
+	}
+}
+
+// Insert adds a row to the buffer. Values should be passed in the order of the columns in the query.
+func (b *BatchInserter) Insert(values ...any) {
+	b.rowsMutex.Lock()
+	b.rows = append(b.rows, values)
+	full := len(b.rows) >= b.config.MaxBatchSize
+	b.rowsMutex.Unlock()
+
+	if full {
+		select {
+		case b.flushChannel <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Metrics returns current statistics of the BatchInserter.
+func (b *BatchInserter) Metrics() BatchInserterMetrics {
+	b.rowsMutex.Lock()
+	pending := len(b.rows)
+	b.rowsMutex.Unlock()
+
+	return BatchInserterMetrics{
+		RowsInserted:  atomic.LoadUint64(&b.metrics.rowsInserted),
+		RowsFailed:    atomic.LoadUint64(&b.metrics.rowsFailed),
+		Batches:       atomic.LoadUint64(&b.metrics.batches),
+		FailedBatches: atomic.LoadUint64(&b.metrics.failedBatches),
+		Pending:       pending,
+	}
+}
+
+// Start implements the interface of tiny.Service.
+func (b *BatchInserter) Start() error {
+	b.stateLock.Lock()
+	if b.started {
+		b.stateLock.Unlock()
+		return errors.New("batch inserter already started")
+	}
+	if b.stopped {
+		b.stateLock.Unlock()
+		return nil
+	}
+	b.started = true
+	b.stateLock.Unlock()
+
+	defer close(b.doneChannel)
+
+	ticker := time.NewTicker(b.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stopChannel:
+			b.flush()
+			return nil
+		case <-ticker.C:
+			b.flush()
+		case <-b.flushChannel:
+			b.flush()
+		}
+	}
+}
+
+// Stop implements the interface of tiny.Service.
+// It waits for the buffered rows to be flushed, but no longer than ShutdownTimeout.
+func (b *BatchInserter) Stop() {
+	b.stopOnce.Do(func() {
+		close(b.stopChannel)
+	})
+
+	b.stateLock.Lock()
+	b.stopped = true
+	started := b.started
+	b.stateLock.Unlock()
+
+	if !started {
+		b.flush()
+		return
+	}
+
+	select {
+	case <-b.doneChannel:
+	case <-time.After(b.config.ShutdownTimeout):
+		log.Error().Msg("Timeout while waiting for the buffered rows to be inserted into ClickHouse")
+	}
+}
+
+func (b *BatchInserter) flush() {
+	b.rowsMutex.Lock()
+	rows := b.rows
+	b.rows = nil
+	b.rowsMutex.Unlock()
+
+	if len(rows) == 0 {
+		return
+	}
+
+	if err := b.send(rows); err != nil {
+		atomic.AddUint64(&b.metrics.failedBatches, 1)
+		atomic.AddUint64(&b.metrics.rowsFailed, uint64(len(rows)))
+
+		log.Error().Err(err).Msgf("Failed to insert batch of %d rows into ClickHouse", len(rows))
+		return
+	}
+
+	atomic.AddUint64(&b.metrics.batches, 1)
+	atomic.AddUint64(&b.metrics.rowsInserted, uint64(len(rows)))
+}
+
+func (b *BatchInserter) send(rows [][]any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.config.FlushTimeout)
+	defer cancel()
+
+	batch, err := b.conn.PrepareBatch(ctx, b.query)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if err := batch.Append(row...); err != nil {
+			_ = batch.Abort()
+			return err
+		}
+	}
+
+	return batch.Send()
+}
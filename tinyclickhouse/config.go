@@ -0,0 +1,144 @@
+package tinyclickhouse
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+)
+
+// Config holds a configuration for Dial.
+type Config struct {
+	// Database is a name of the default database (default: "default").
+	Database string
+
+	// Username is a name of the user to authenticate as (default: "default").
+	Username string
+
+	// Password is a password of the user.
+	Password string
+
+	// TLSConfig is an optional TLS configuration. When specified - enables TLS mode.
+	TLSConfig *tls.Config
+
+	// Compression specifies whether to compress the data sent over the wire with LZ4 (default: false).
+	Compression bool
+
+	// DialTimeout is a maximum time spent on establishing a single connection (default: 5s).
+	DialTimeout time.Duration
+
+	// PoolMaxOpen is the maximum number of open connections to the database (default: 10).
+	PoolMaxOpen int
+
+	// PoolMaxIdle is the maximum number of connections in the idle connection pool (default: 5).
+	PoolMaxIdle int
+
+	// PoolMaxLifetime is the maximum amount of time a connection may be reused (default: 1h).
+	PoolMaxLifetime time.Duration
+
+	// NoPing indicates whether Dial should skip the initial call to Ping method (default: false).
+	NoPing bool
+
+	// Verbose specifies whether to log the debug output of the driver.
+	Verbose bool
+
+	// ClickhouseOpt allows to specify a function that operates directly on *clickhouse.Options.
+	ClickhouseOpt func(*clickhouse.Options)
+}
+
+// BatchInserterConfig holds a configuration for NewBatchInserter.
+type BatchInserterConfig struct {
+	// MaxBatchSize is a number of buffered rows that triggers a flush (default: 10000).
+	MaxBatchSize int
+
+	// FlushInterval is a time after which the buffer is flushed regardless of its size (default: 1s).
+	FlushInterval time.Duration
+
+	// FlushTimeout is a maximum time a single flush can take (default: 30s).
+	FlushTimeout time.Duration
+
+	// ShutdownTimeout is a maximum time Stop waits for the buffered rows to be flushed (default: 1m).
+	ShutdownTimeout time.Duration
+}
+
+func mergeConfig(provided *Config) *Config {
+	config := &Config{
+		Database:        "default",
+		Username:        "default",
+		DialTimeout:     5 * time.Second,
+		PoolMaxOpen:     10,
+		PoolMaxIdle:     5,
+		PoolMaxLifetime: time.Hour,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.Database != "" {
+		config.Database = provided.Database
+	}
+	if provided.Username != "" {
+		config.Username = provided.Username
+	}
+	if provided.Password != "" {
+		config.Password = provided.Password
+	}
+	if provided.TLSConfig != nil {
+		config.TLSConfig = provided.TLSConfig
+	}
+	if provided.Compression {
+		config.Compression = true
+	}
+	if provided.DialTimeout > 0 {
+		config.DialTimeout = provided.DialTimeout
+	}
+	if provided.PoolMaxOpen > 0 {
+		config.PoolMaxOpen = provided.PoolMaxOpen
+	}
+	if provided.PoolMaxIdle > 0 {
+		config.PoolMaxIdle = provided.PoolMaxIdle
+	}
+	if provided.PoolMaxLifetime > 0 {
+		config.PoolMaxLifetime = provided.PoolMaxLifetime
+	}
+	if provided.NoPing {
+		config.NoPing = true
+	}
+	if provided.Verbose {
+		config.Verbose = true
+	}
+	if provided.ClickhouseOpt != nil {
+		config.ClickhouseOpt = provided.ClickhouseOpt
+	}
+
+	return config
+}
+
+func mergeBatchInserterConfig(provided *BatchInserterConfig) *BatchInserterConfig {
+	config := &BatchInserterConfig{
+		MaxBatchSize:    10000,
+		FlushInterval:   time.Second,
+		FlushTimeout:    30 * time.Second,
+		ShutdownTimeout: time.Minute,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.MaxBatchSize > 0 {
+		config.MaxBatchSize = provided.MaxBatchSize
+	}
+	if provided.FlushInterval > 0 {
+		config.FlushInterval = provided.FlushInterval
+	}
+	if provided.FlushTimeout > 0 {
+		config.FlushTimeout = provided.FlushTimeout
+	}
+	if provided.ShutdownTimeout > 0 {
+		config.ShutdownTimeout = provided.ShutdownTimeout
+	}
+
+	return config
+}
@@ -0,0 +1,69 @@
+package tinyclickhouse
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/rs/zerolog/log"
+)
+
+// Dial creates a connection pool to ClickHouse servers available under given addresses,
+// using the native protocol, and returns driver.Conn instance.
+func Dial(addresses []string, config ...*Config) (driver.Conn, error) {
+	var providedConfig *Config
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeConfig(providedConfig)
+
+	if len(addresses) == 0 {
+		return nil, errors.New("addresses cannot be empty")
+	}
+
+	options := &clickhouse.Options{
+		Addr: addresses,
+		Auth: clickhouse.Auth{
+			Database: c.Database,
+			Username: c.Username,
+			Password: c.Password,
+		},
+		TLS:             c.TLSConfig,
+		DialTimeout:     c.DialTimeout,
+		MaxOpenConns:    c.PoolMaxOpen,
+		MaxIdleConns:    c.PoolMaxIdle,
+		ConnMaxLifetime: c.PoolMaxLifetime,
+		Debug:           c.Verbose,
+		Debugf: func(format string, v ...interface{}) {
+			log.Debug().Msgf(format, v...)
+		},
+	}
+
+	if c.Compression {
+		options.Compression = &clickhouse.Compression{
+			Method: clickhouse.CompressionLZ4,
+		}
+	}
+
+	if c.ClickhouseOpt != nil {
+		c.ClickhouseOpt(options)
+	}
+
+	conn, err := clickhouse.Open(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.NoPing {
+		ctx, cancel := context.WithTimeout(context.Background(), c.DialTimeout)
+		defer cancel()
+
+		if err := conn.Ping(ctx); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
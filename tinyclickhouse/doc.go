@@ -0,0 +1,4 @@
+/*
+Package tinyclickhouse provides utilities for handling ClickHouse.
+*/
+package tinyclickhouse
@@ -0,0 +1,91 @@
+package tinyclickhouse
+
+import (
+	"errors"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+)
+
+const (
+	// ErrUnknown is returned for all errors with unknown cause.
+	ErrUnknown = iota
+
+	// ErrSyntax is returned when the query cannot be parsed.
+	ErrSyntax
+
+	// ErrUnknownTable is returned when the query references a table that does not exist.
+	ErrUnknownTable
+
+	// ErrUnknownDatabase is returned when the query references a database that does not exist.
+	ErrUnknownDatabase
+
+	// ErrUnknownIdentifier is returned when the query references a column that does not exist.
+	ErrUnknownIdentifier
+
+	// ErrTableAlreadyExists is returned when trying to create a table that already exists.
+	ErrTableAlreadyExists
+
+	// ErrAuthentication is returned when the credentials are invalid.
+	ErrAuthentication
+
+	// ErrTimeout is returned when the query exceeds its time limit.
+	ErrTimeout
+
+	// ErrMemoryLimitExceeded is returned when the query exceeds its memory limit.
+	ErrMemoryLimitExceeded
+)
+
+// Error represents a wrapped query error.
+type Error struct {
+	// Err is the wrapped error.
+	Err error
+
+	// Code is the error code.
+	Code int
+
+	// ServerCode is the numeric code of the exception returned by the server.
+	ServerCode int32
+
+	// Name is the name of the exception returned by the server.
+	Name string
+
+	// Message is the message returned by the server.
+	Message string
+}
+
+// TranslateError tries to cast given error instance to clickhouse.Exception
+// and extract enough information to build Error instance.
+func TranslateError(err error) *Error {
+	result := &Error{
+		Err:  err,
+		Code: ErrUnknown,
+	}
+
+	var exception *clickhouse.Exception
+	if errors.As(err, &exception) {
+		result.ServerCode = exception.Code
+		result.Name = exception.Name
+		result.Message = exception.Message
+
+		switch exception.Code {
+		case 62: // SYNTAX_ERROR
+			result.Code = ErrSyntax
+		case 60: // UNKNOWN_TABLE
+			result.Code = ErrUnknownTable
+		case 81: // UNKNOWN_DATABASE
+			result.Code = ErrUnknownDatabase
+		case 47: // UNKNOWN_IDENTIFIER
+			result.Code = ErrUnknownIdentifier
+		case 57: // TABLE_ALREADY_EXISTS
+			result.Code = ErrTableAlreadyExists
+		case 516: // AUTHENTICATION_FAILED
+			result.Code = ErrAuthentication
+		case 159: // TIMEOUT_EXCEEDED
+			result.Code = ErrTimeout
+		case 241: // MEMORY_LIMIT_EXCEEDED
+			result.Code = ErrMemoryLimitExceeded
+		}
+	}
+
+	return result
+}
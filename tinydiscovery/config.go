@@ -0,0 +1,62 @@
+package tinydiscovery
+
+import (
+	"time"
+
+	"github.com/mkorman9/tiny/tinyhttp/requests"
+)
+
+// ConsulConfig holds a configuration for NewRegistrar and NewResolver.
+type ConsulConfig struct {
+	// Token is an ACL token used to authorize requests to Consul.
+	Token string
+
+	// TTL is a time after which the service is marked as unhealthy if no heartbeat is received (default: 15s).
+	TTL time.Duration
+
+	// HeartbeatInterval is a time between subsequent heartbeats (default: 5s).
+	HeartbeatInterval time.Duration
+
+	// DeregisterCriticalAfter is a time after which Consul removes a service that remains unhealthy (default: 1m).
+	DeregisterCriticalAfter time.Duration
+
+	// RefreshInterval is a time between subsequent lookups of the gRPC resolver (default: 10s).
+	RefreshInterval time.Duration
+
+	// Client is a configuration of the underlying HTTP client.
+	Client *requests.Config
+}
+
+func mergeConsulConfig(provided *ConsulConfig) *ConsulConfig {
+	config := &ConsulConfig{
+		TTL:                     15 * time.Second,
+		HeartbeatInterval:       5 * time.Second,
+		DeregisterCriticalAfter: time.Minute,
+		RefreshInterval:         10 * time.Second,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.Token != "" {
+		config.Token = provided.Token
+	}
+	if provided.TTL > 0 {
+		config.TTL = provided.TTL
+	}
+	if provided.HeartbeatInterval > 0 {
+		config.HeartbeatInterval = provided.HeartbeatInterval
+	}
+	if provided.DeregisterCriticalAfter > 0 {
+		config.DeregisterCriticalAfter = provided.DeregisterCriticalAfter
+	}
+	if provided.RefreshInterval > 0 {
+		config.RefreshInterval = provided.RefreshInterval
+	}
+	if provided.Client != nil {
+		config.Client = provided.Client
+	}
+
+	return config
+}
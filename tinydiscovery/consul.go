@@ -0,0 +1,69 @@
+package tinydiscovery
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mkorman9/tiny/tinyhttp/requests"
+)
+
+type consulClient struct {
+	baseURL string
+	config  *ConsulConfig
+	client  *requests.Client
+}
+
+type consulServiceEntry struct {
+	Node struct {
+		Address string
+	}
+	Service struct {
+		Address string
+		Port    int
+	}
+}
+
+func newConsulClient(baseURL string, config *ConsulConfig) *consulClient {
+	return &consulClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		config:  config,
+		client:  requests.NewClient(config.Client),
+	}
+}
+
+func (c *consulClient) call(method, path string, body any, out any) error {
+	opts := []requests.RequestOpt{
+		requests.Method(method),
+	}
+	if body != nil {
+		opts = append(opts, requests.JSONBody(body))
+	}
+	if c.config.Token != "" {
+		opts = append(opts, requests.Header("X-Consul-Token", c.config.Token))
+	}
+
+	request, err := requests.NewRequest(c.baseURL+path, opts...)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.client.Send(request)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		responseBody, _ := io.ReadAll(response.Body)
+		_ = response.Body.Close()
+		return fmt.Errorf("consul returned status %d: %s", response.StatusCode, string(responseBody))
+	}
+
+	if out == nil {
+		_ = response.Body.Close()
+		return nil
+	}
+
+	return requests.ReadResponseJSON(response, out)
+}
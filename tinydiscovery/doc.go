@@ -0,0 +1,4 @@
+/*
+Package tinydiscovery provides service registration and discovery based on Consul.
+*/
+package tinydiscovery
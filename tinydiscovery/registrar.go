@@ -0,0 +1,164 @@
+package tinydiscovery
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Addressable is implemented by servers exposing the address they listen on, such as tinyhttp.Server
+// and tinygrpc.Server.
+type Addressable interface {
+	// Address returns the listening address in "host:port" format.
+	Address() string
+}
+
+// Registration describes a service instance registered in Consul.
+type Registration struct {
+	// ID is a unique identifier of the instance (default: "name-hostname-port").
+	ID string
+
+	// Name is a name of the service.
+	Name string
+
+	// Address is an address other services should use to connect to the instance.
+	Address string
+
+	// Port is a port the instance is listening on.
+	Port int
+
+	// Tags is a list of tags attached to the instance.
+	Tags []string
+
+	// Meta is a set of metadata attached to the instance.
+	Meta map[string]string
+}
+
+// Registrar is a Service that registers an instance in Consul, keeps its TTL health check passing
+// and deregisters the instance when stopped.
+type Registrar struct {
+	consul       *consulClient
+	registration *Registration
+	stopChannel  chan struct{}
+}
+
+// RegistrationFor creates a Registration for given server.
+// When the server listens on all interfaces, the hostname of the machine is used as the address.
+func RegistrationFor(name string, server Addressable) (*Registration, error) {
+	host, portValue, err := net.SplitHostPort(server.Address())
+	if err != nil {
+		return nil, err
+	}
+
+	port, err := strconv.Atoi(portValue)
+	if err != nil {
+		return nil, err
+	}
+	if port == 0 {
+		return nil, errors.New("server must listen on a fixed port")
+	}
+
+	if host == "" || net.ParseIP(host).IsUnspecified() {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, err
+		}
+
+		host = hostname
+	}
+
+	return &Registration{
+		Name:    name,
+		Address: host,
+		Port:    port,
+	}, nil
+}
+
+// NewRegistrar creates new Registrar for the Consul agent available under given URL,
+// for example "http://localhost:8500".
+func NewRegistrar(consulURL string, registration *Registration, config ...*ConsulConfig) *Registrar {
+	var providedConfig *ConsulConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeConsulConfig(providedConfig)
+
+	if registration.ID == "" {
+		hostname, _ := os.Hostname()
+		registration.ID = fmt.Sprintf("%s-%s-%d", registration.Name, hostname, registration.Port)
+	}
+
+	return &Registrar{
+		consul:       newConsulClient(consulURL, c),
+		registration: registration,
+		stopChannel:  make(chan struct{}, 1),
+	}
+}
+
+// Start implements the interface of tiny.Service.
+func (r *Registrar) Start() error {
+	err := r.consul.call("PUT", "/v1/agent/service/register", map[string]any{
+		"ID":      r.registration.ID,
+		"Name":    r.registration.Name,
+		"Address": r.registration.Address,
+		"Port":    r.registration.Port,
+		"Tags":    r.registration.Tags,
+		"Meta":    r.registration.Meta,
+		"Check": map[string]any{
+			"CheckID":                        r.checkID(),
+			"TTL":                            r.consul.config.TTL.String(),
+			"DeregisterCriticalServiceAfter": r.consul.config.DeregisterCriticalAfter.String(),
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	log.Info().Msgf("Service registered in Consul (%s)", r.registration.ID)
+
+	r.heartbeat()
+
+	ticker := time.NewTicker(r.consul.config.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopChannel:
+			return nil
+		case <-ticker.C:
+			r.heartbeat()
+		}
+	}
+}
+
+// Stop implements the interface of tiny.Service.
+func (r *Registrar) Stop() {
+	path := "/v1/agent/service/deregister/" + url.PathEscape(r.registration.ID)
+	if err := r.consul.call("PUT", path, nil, nil); err != nil {
+		log.Error().Err(err).Msgf("Failed to deregister service from Consul (%s)", r.registration.ID)
+	} else {
+		log.Info().Msgf("Service deregistered from Consul (%s)", r.registration.ID)
+	}
+
+	select {
+	case r.stopChannel <- struct{}{}:
+	default:
+	}
+}
+
+func (r *Registrar) heartbeat() {
+	path := "/v1/agent/check/pass/" + url.PathEscape(r.checkID())
+	if err := r.consul.call("PUT", path, nil, nil); err != nil {
+		log.Warn().Err(err).Msgf("Failed to send heartbeat to Consul (%s)", r.registration.ID)
+	}
+}
+
+func (r *Registrar) checkID() string {
+	return "service:" + r.registration.ID
+}
@@ -0,0 +1,188 @@
+package tinydiscovery
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	grpcresolver "google.golang.org/grpc/resolver"
+)
+
+var (
+	// ErrNoInstances is returned when there are no healthy instances of the service.
+	ErrNoInstances = errors.New("no healthy instances of the service")
+)
+
+// Resolver looks up healthy instances of services registered in Consul.
+type Resolver struct {
+	consul  *consulClient
+	counter uint64
+}
+
+// NewResolver creates new Resolver for the Consul agent available under given URL,
+// for example "http://localhost:8500".
+func NewResolver(consulURL string, config ...*ConsulConfig) *Resolver {
+	var providedConfig *ConsulConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeConsulConfig(providedConfig)
+
+	return &Resolver{
+		consul: newConsulClient(consulURL, c),
+	}
+}
+
+// Resolve returns addresses ("host:port") of all healthy instances of the service with given name.
+func (r *Resolver) Resolve(name string) ([]string, error) {
+	var entries []consulServiceEntry
+
+	path := fmt.Sprintf("/v1/health/service/%s?passing=true", url.PathEscape(name))
+	if err := r.consul.call("GET", path, nil, &entries); err != nil {
+		return nil, err
+	}
+
+	var addresses []string
+	for _, entry := range entries {
+		host := entry.Service.Address
+		if host == "" {
+			host = entry.Node.Address
+		}
+
+		addresses = append(addresses, net.JoinHostPort(host, strconv.Itoa(entry.Service.Port)))
+	}
+
+	return addresses, nil
+}
+
+// Pick returns an address of one of the healthy instances of the service, selected in round-robin fashion.
+func (r *Resolver) Pick(name string) (string, error) {
+	addresses, err := r.Resolve(name)
+	if err != nil {
+		return "", err
+	}
+
+	if len(addresses) == 0 {
+		return "", ErrNoInstances
+	}
+
+	n := atomic.AddUint64(&r.counter, 1)
+	return addresses[n%uint64(len(addresses))], nil
+}
+
+// URL returns an URL of one of the healthy instances of the service, to be used with requests.NewRequest.
+func (r *Resolver) URL(name, scheme, path string) (string, error) {
+	address, err := r.Pick(name)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, address, path), nil
+}
+
+// GRPCResolver returns a gRPC resolver builder for the "consul" scheme.
+// Pass it to grpc.WithResolvers and dial "consul:///service-name".
+func (r *Resolver) GRPCResolver() grpcresolver.Builder {
+	return &grpcResolverBuilder{resolver: r}
+}
+
+type grpcResolverBuilder struct {
+	resolver *Resolver
+}
+
+type grpcResolver struct {
+	resolver     *Resolver
+	name         string
+	clientConn   grpcresolver.ClientConn
+	stopChannel  chan struct{}
+	resolveNow   chan struct{}
+	closeOnce    sync.Once
+	lastResolved []string
+}
+
+func (b *grpcResolverBuilder) Build(
+	target grpcresolver.Target,
+	clientConn grpcresolver.ClientConn,
+	_ grpcresolver.BuildOptions,
+) (grpcresolver.Resolver, error) {
+	name := target.Endpoint
+	if name == "" {
+		return nil, errors.New("service name cannot be empty")
+	}
+
+	resolver := &grpcResolver{
+		resolver:    b.resolver,
+		name:        name,
+		clientConn:  clientConn,
+		stopChannel: make(chan struct{}),
+		resolveNow:  make(chan struct{}, 1),
+	}
+
+	go resolver.watch()
+
+	return resolver, nil
+}
+
+func (b *grpcResolverBuilder) Scheme() string {
+	return "consul"
+}
+
+func (r *grpcResolver) ResolveNow(_ grpcresolver.ResolveNowOptions) {
+	select {
+	case r.resolveNow <- struct{}{}:
+	default:
+	}
+}
+
+func (r *grpcResolver) Close() {
+	r.closeOnce.Do(func() {
+		close(r.stopChannel)
+	})
+}
+
+func (r *grpcResolver) watch() {
+	ticker := time.NewTicker(r.resolver.consul.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		r.update()
+
+		select {
+		case <-r.stopChannel:
+			return
+		case <-ticker.C:
+		case <-r.resolveNow:
+		}
+	}
+}
+
+func (r *grpcResolver) update() {
+	addresses, err := r.resolver.Resolve(r.name)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed to resolve service from Consul (%s)", r.name)
+		r.clientConn.ReportError(err)
+		return
+	}
+
+	if len(addresses) == 0 && len(r.lastResolved) == 0 {
+		r.clientConn.ReportError(ErrNoInstances)
+		return
+	}
+
+	var state grpcresolver.State
+	for _, address := range addresses {
+		state.Addresses = append(state.Addresses, grpcresolver.Address{Addr: address})
+	}
+
+	r.lastResolved = addresses
+
+	if err := r.clientConn.UpdateState(state); err != nil {
+		log.Debug().Err(err).Msgf("Failed to update gRPC resolver state (%s)", r.name)
+	}
+}
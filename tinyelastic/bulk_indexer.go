@@ -0,0 +1,99 @@
+package tinyelastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esutil"
+	"github.com/rs/zerolog/log"
+)
+
+// BulkIndexer is a Service that buffers documents and sends them to Elasticsearch in bulk requests.
+// Buffer is flushed periodically and when the service is stopped.
+type BulkIndexer struct {
+	esutil.BulkIndexer
+
+	config      *BulkIndexerConfig
+	stopChannel chan struct{}
+}
+
+// NewBulkIndexer creates new BulkIndexer using given client.
+func NewBulkIndexer(client *elasticsearch.Client, config ...*BulkIndexerConfig) (*BulkIndexer, error) {
+	var providedConfig *BulkIndexerConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeBulkIndexerConfig(providedConfig)
+
+	indexer, err := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
+		Client:        client,
+		Index:         c.Index,
+		NumWorkers:    c.NumWorkers,
+		FlushBytes:    c.FlushBytes,
+		FlushInterval: c.FlushInterval,
+		OnError: func(_ context.Context, err error) {
+			log.Error().Err(err).Msg("Elasticsearch bulk indexer error")
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BulkIndexer{
+		BulkIndexer: indexer,
+		config:      c,
+		stopChannel: make(chan struct{}, 1),
+	}, nil
+}
+
+// Index adds a document encoded to JSON to the buffer. Empty index means the default index from configuration.
+// Empty id lets Elasticsearch generate a new one.
+func (b *BulkIndexer) Index(index, id string, document any) error {
+	body, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+
+	return b.Add(context.Background(), esutil.BulkIndexerItem{
+		Action:     "index",
+		Index:      index,
+		DocumentID: id,
+		Body:       bytes.NewReader(body),
+		OnFailure: func(
+			_ context.Context,
+			item esutil.BulkIndexerItem,
+			response esutil.BulkIndexerResponseItem,
+			err error,
+		) {
+			if err == nil {
+				log.Error().Msgf("Failed to index document: %s", response.Error.Reason)
+			} else {
+				log.Error().Err(err).Msg("Failed to index document")
+			}
+		},
+	})
+}
+
+// Start implements the interface of tiny.Service.
+func (b *BulkIndexer) Start() error {
+	<-b.stopChannel
+	return nil
+}
+
+// Stop implements the interface of tiny.Service.
+// It flushes all the buffered documents, waiting no longer than ShutdownTimeout.
+func (b *BulkIndexer) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), b.config.ShutdownTimeout)
+	defer cancel()
+
+	if err := b.Close(ctx); err != nil {
+		log.Error().Err(err).Msg("Error while flushing Elasticsearch bulk indexer")
+	}
+
+	select {
+	case b.stopChannel <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,89 @@
+package tinyelastic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// Dial creates a client for Elasticsearch cluster available under given addresses,
+// and returns *elasticsearch.Client instance.
+func Dial(addresses []string, config ...*Config) (*elasticsearch.Client, error) {
+	var providedConfig *Config
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeConfig(providedConfig)
+
+	if len(addresses) == 0 && c.CloudID == "" {
+		return nil, errors.New("addresses cannot be empty")
+	}
+
+	elasticConfig := elasticsearch.Config{
+		Addresses:  addresses,
+		Username:   c.Username,
+		Password:   c.Password,
+		APIKey:     c.APIKey,
+		CloudID:    c.CloudID,
+		MaxRetries: c.MaxRetries,
+		Logger:     &elasticLogger{verbose: c.Verbose},
+	}
+
+	if c.TLSConfig != nil {
+		elasticConfig.Transport = &http.Transport{
+			TLSClientConfig: c.TLSConfig,
+		}
+	}
+
+	if c.ElasticOpt != nil {
+		c.ElasticOpt(&elasticConfig)
+	}
+
+	client, err := elasticsearch.NewClient(elasticConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.NoPing {
+		ctx, cancel := context.WithTimeout(context.Background(), c.ConnectionTimeout)
+		defer cancel()
+
+		if err := HealthCheck(ctx, client); err != nil {
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
+// HealthCheck queries the health of the cluster and returns an error if the cluster is unreachable
+// or its status is red.
+func HealthCheck(ctx context.Context, client *elasticsearch.Client) error {
+	response, err := client.Cluster.Health(client.Cluster.Health.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.IsError() {
+		return fmt.Errorf("cluster health check failed: %s", response.Status())
+	}
+
+	var health struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&health); err != nil {
+		return err
+	}
+
+	if health.Status == "red" {
+		return errors.New("cluster status is red")
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package tinyelastic
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// Config holds a configuration for Dial.
+type Config struct {
+	// Username is a username for HTTP Basic Authentication.
+	Username string
+
+	// Password is a password for HTTP Basic Authentication.
+	Password string
+
+	// APIKey is a base64-encoded API key. If set, it overrides Username and Password.
+	APIKey string
+
+	// CloudID is an endpoint for the Elastic Cloud. It cannot be used together with addresses passed to Dial.
+	CloudID string
+
+	// TLSConfig is an optional TLS configuration to use when connecting to HTTPS endpoints.
+	TLSConfig *tls.Config
+
+	// MaxRetries is a maximum number of retries for a failed request (default: 3).
+	MaxRetries int
+
+	// ConnectionTimeout is a maximum time client should spend trying to connect (default: 5s).
+	ConnectionTimeout time.Duration
+
+	// NoPing indicates whether Dial should skip the initial health check (default: false).
+	NoPing bool
+
+	// Verbose specifies whether to log all executed requests.
+	Verbose bool
+
+	// ElasticOpt allows to specify a function that operates directly on *elasticsearch.Config.
+	ElasticOpt func(*elasticsearch.Config)
+}
+
+// BulkIndexerConfig holds a configuration for NewBulkIndexer.
+type BulkIndexerConfig struct {
+	// Index is a default index for items that don't specify one.
+	Index string
+
+	// NumWorkers is a number of workers flushing the data (default: 1).
+	NumWorkers int
+
+	// FlushBytes is a size threshold after which the buffer is flushed (default: 5MB).
+	FlushBytes int
+
+	// FlushInterval is a time after which the buffer is flushed regardless of its size (default: 30s).
+	FlushInterval time.Duration
+
+	// ShutdownTimeout is a maximum time to wait for the final flush when stopping the indexer (default: 30s).
+	ShutdownTimeout time.Duration
+}
+
+func mergeConfig(provided *Config) *Config {
+	config := &Config{
+		MaxRetries:        3,
+		ConnectionTimeout: 5 * time.Second,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.Username != "" {
+		config.Username = provided.Username
+	}
+	if provided.Password != "" {
+		config.Password = provided.Password
+	}
+	if provided.APIKey != "" {
+		config.APIKey = provided.APIKey
+	}
+	if provided.CloudID != "" {
+		config.CloudID = provided.CloudID
+	}
+	if provided.TLSConfig != nil {
+		config.TLSConfig = provided.TLSConfig
+	}
+	if provided.MaxRetries > 0 {
+		config.MaxRetries = provided.MaxRetries
+	}
+	if provided.ConnectionTimeout > 0 {
+		config.ConnectionTimeout = provided.ConnectionTimeout
+	}
+	if provided.NoPing {
+		config.NoPing = true
+	}
+	if provided.Verbose {
+		config.Verbose = true
+	}
+	if provided.ElasticOpt != nil {
+		config.ElasticOpt = provided.ElasticOpt
+	}
+
+	return config
+}
+
+func mergeBulkIndexerConfig(provided *BulkIndexerConfig) *BulkIndexerConfig {
+	config := &BulkIndexerConfig{
+		NumWorkers:      1,
+		FlushBytes:      5 * 1024 * 1024,
+		FlushInterval:   30 * time.Second,
+		ShutdownTimeout: 30 * time.Second,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.Index != "" {
+		config.Index = provided.Index
+	}
+	if provided.NumWorkers > 0 {
+		config.NumWorkers = provided.NumWorkers
+	}
+	if provided.FlushBytes > 0 {
+		config.FlushBytes = provided.FlushBytes
+	}
+	if provided.FlushInterval > 0 {
+		config.FlushInterval = provided.FlushInterval
+	}
+	if provided.ShutdownTimeout > 0 {
+		config.ShutdownTimeout = provided.ShutdownTimeout
+	}
+
+	return config
+}
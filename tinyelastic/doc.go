@@ -0,0 +1,4 @@
+/*
+Package tinyelastic provides utilities for handling Elasticsearch.
+*/
+package tinyelastic
@@ -0,0 +1,48 @@
+package tinyelastic
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type elasticLogger struct {
+	verbose bool
+}
+
+func (l *elasticLogger) LogRoundTrip(
+	request *http.Request,
+	response *http.Response,
+	err error,
+	_ time.Time,
+	elapsed time.Duration,
+) error {
+	if err != nil {
+		log.Warn().Err(err).Msgf("Elasticsearch error for: '%s %s'", request.Method, request.URL.Path)
+	} else if response != nil && response.StatusCode >= http.StatusInternalServerError {
+		log.Warn().Msgf(
+			"Elasticsearch error for: '%s %s' [%d]",
+			request.Method,
+			request.URL.Path,
+			response.StatusCode,
+		)
+	} else if l.verbose {
+		status := 0
+		if response != nil {
+			status = response.StatusCode
+		}
+
+		log.Debug().Msgf("Elasticsearch request (%v) [%d]: '%s %s'", elapsed.String(), status, request.Method, request.URL.Path)
+	}
+
+	return nil
+}
+
+func (l *elasticLogger) RequestBodyEnabled() bool {
+	return false
+}
+
+func (l *elasticLogger) ResponseBodyEnabled() bool {
+	return false
+}
@@ -0,0 +1,41 @@
+package tinyflags
+
+import (
+	"time"
+
+	"github.com/mkorman9/tiny/tinyhttp/requests"
+)
+
+// HTTPProviderConfig holds a configuration for NewHTTPProvider.
+type HTTPProviderConfig struct {
+	// PollInterval is a time between subsequent fetches of the flags (default: 30s).
+	PollInterval time.Duration
+
+	// Headers is a set of headers attached to each request, for example authorization tokens.
+	Headers map[string]string
+
+	// Client is a configuration of the underlying HTTP client.
+	Client *requests.Config
+}
+
+func mergeHTTPProviderConfig(provided *HTTPProviderConfig) *HTTPProviderConfig {
+	config := &HTTPProviderConfig{
+		PollInterval: 30 * time.Second,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.PollInterval > 0 {
+		config.PollInterval = provided.PollInterval
+	}
+	if provided.Headers != nil {
+		config.Headers = provided.Headers
+	}
+	if provided.Client != nil {
+		config.Client = provided.Client
+	}
+
+	return config
+}
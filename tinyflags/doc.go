@@ -0,0 +1,4 @@
+/*
+Package tinyflags provides evaluation of feature flags with percentage rollouts and per-request targeting.
+*/
+package tinyflags
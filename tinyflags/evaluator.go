@@ -0,0 +1,63 @@
+package tinyflags
+
+// Provider is a source of flag definitions.
+type Provider interface {
+	// Flag returns a definition of the flag with given name, or false if the provider does not define it.
+	Flag(name string) (*Flag, bool)
+}
+
+// Evaluator evaluates flags using a chain of providers. The first provider defining the flag wins.
+type Evaluator struct {
+	providers []Provider
+}
+
+// NewEvaluator creates new Evaluator using given providers, in the order of precedence.
+func NewEvaluator(providers ...Provider) *Evaluator {
+	return &Evaluator{
+		providers: providers,
+	}
+}
+
+// Bool returns true if the flag is turned on for the given target.
+// Returns defaultValue if none of the providers defines the flag.
+func (e *Evaluator) Bool(name string, defaultValue bool, target ...*Target) bool {
+	flag, ok := e.lookup(name)
+	if !ok {
+		return defaultValue
+	}
+
+	return flag.isOn(name, firstTarget(target))
+}
+
+// String returns the value of the flag if it's turned on for the given target.
+// Returns defaultValue if the flag is turned off, has no value or none of the providers defines it.
+func (e *Evaluator) String(name string, defaultValue string, target ...*Target) string {
+	flag, ok := e.lookup(name)
+	if !ok || flag.Value == "" {
+		return defaultValue
+	}
+
+	if !flag.isOn(name, firstTarget(target)) {
+		return defaultValue
+	}
+
+	return flag.Value
+}
+
+func (e *Evaluator) lookup(name string) (*Flag, bool) {
+	for _, provider := range e.providers {
+		if flag, ok := provider.Flag(name); ok {
+			return flag, true
+		}
+	}
+
+	return nil, false
+}
+
+func firstTarget(target []*Target) *Target {
+	if target != nil {
+		return target[0]
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package tinyflags
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPercentageRollout(t *testing.T) {
+	// given
+	percentage := 50.0
+	evaluator := NewEvaluator(StaticProvider{
+		"new-checkout": &Flag{Enabled: true, Percentage: &percentage, Targets: []string{"vip"}},
+	})
+
+	// when
+	enabled := 0
+	for i := 0; i < 1000; i++ {
+		target := &Target{Key: string(rune('a'+i%26)) + string(rune('a'+i/26))}
+		if evaluator.Bool("new-checkout", false, target) {
+			enabled++
+		}
+	}
+
+	// then
+	assert.InDelta(t, 500, enabled, 100, "roughly half of the targets should be enabled")
+	assert.True(t, evaluator.Bool("new-checkout", false, &Target{Key: "vip"}), "listed target should be enabled")
+	assert.False(t, evaluator.Bool("new-checkout", false), "anonymous target should be disabled")
+	assert.True(t, evaluator.Bool("missing", true), "missing flag should fall back to default")
+}
@@ -0,0 +1,81 @@
+package tinyflags
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// Flag holds a definition of a feature flag.
+type Flag struct {
+	// Enabled decides whether the flag is turned on.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Value is a value returned by string flags when the flag is turned on for given target.
+	Value string `json:"value" mapstructure:"value"`
+
+	// Percentage limits an enabled flag to given percentage (0-100) of targets, selected by their keys.
+	// Nil means all the targets.
+	Percentage *float64 `json:"percentage" mapstructure:"percentage"`
+
+	// Targets is a list of target keys the flag is always turned on for.
+	Targets []string `json:"targets" mapstructure:"targets"`
+}
+
+// Target represents an entity flags are evaluated for, such as a user or a tenant.
+type Target struct {
+	// Key uniquely identifies the target. It's used to select targets in percentage rollouts.
+	Key string
+
+	// Attributes holds additional information about the target.
+	Attributes map[string]string
+}
+
+func (f *Flag) isOn(name string, target *Target) bool {
+	if target != nil && target.Key != "" {
+		for _, key := range f.Targets {
+			if key == target.Key {
+				return true
+			}
+		}
+	}
+
+	if !f.Enabled {
+		return false
+	}
+
+	if f.Percentage == nil || *f.Percentage >= 100 {
+		return true
+	}
+
+	if target == nil || target.Key == "" {
+		return false
+	}
+
+	return bucket(name, target.Key) < *f.Percentage
+}
+
+// bucket deterministically assigns the target to a value in range [0, 100), separately for each flag.
+func bucket(name, key string) float64 {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name + ":" + key))
+	return float64(hash.Sum32()%10000) / 100
+}
+
+// parseFlag converts a textual flag definition into a Flag.
+// "true" and "false" are treated as booleans, values like "25%" as rollouts and everything else as string values.
+func parseFlag(value string) *Flag {
+	value = strings.TrimSpace(value)
+
+	if enabled, err := strconv.ParseBool(value); err == nil {
+		return &Flag{Enabled: enabled}
+	}
+
+	if strings.HasSuffix(value, "%") {
+		if percentage, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64); err == nil {
+			return &Flag{Enabled: true, Percentage: &percentage}
+		}
+	}
+
+	return &Flag{Enabled: true, Value: value}
+}
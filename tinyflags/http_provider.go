@@ -0,0 +1,116 @@
+package tinyflags
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mkorman9/tiny/tinyhttp/requests"
+	"github.com/rs/zerolog/log"
+)
+
+// HTTPProvider is a Provider periodically fetching flags from a remote HTTP endpoint.
+// The endpoint is expected to return a JSON object mapping flag names to Flag definitions.
+// HTTPProvider implements the interface of tiny.Service, the polling runs until the service is stopped.
+// Flags from the last successful fetch are served when the endpoint becomes unavailable.
+type HTTPProvider struct {
+	url         string
+	config      *HTTPProviderConfig
+	client      *requests.Client
+	flags       map[string]*Flag
+	flagsMutex  sync.RWMutex
+	stopChannel chan struct{}
+}
+
+// NewHTTPProvider creates new HTTPProvider fetching flags from given URL.
+func NewHTTPProvider(url string, config ...*HTTPProviderConfig) *HTTPProvider {
+	var providedConfig *HTTPProviderConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeHTTPProviderConfig(providedConfig)
+
+	return &HTTPProvider{
+		url:         url,
+		config:      c,
+		client:      requests.NewClient(c.Client),
+		flags:       map[string]*Flag{},
+		stopChannel: make(chan struct{}, 1),
+	}
+}
+
+// Flag implements the interface of Provider.
+func (p *HTTPProvider) Flag(name string) (*Flag, bool) {
+	p.flagsMutex.RLock()
+	defer p.flagsMutex.RUnlock()
+
+	flag, ok := p.flags[name]
+	return flag, ok
+}
+
+// Refresh fetches the flags from the remote endpoint immediately.
+func (p *HTTPProvider) Refresh() error {
+	opts := []requests.RequestOpt{
+		requests.Method("GET"),
+		requests.Header("Accept", "application/json"),
+	}
+	for key, value := range p.config.Headers {
+		opts = append(opts, requests.Header(key, value))
+	}
+
+	request, err := requests.NewRequest(p.url, opts...)
+	if err != nil {
+		return err
+	}
+
+	response, err := p.client.Send(request)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		_ = response.Body.Close()
+		return fmt.Errorf("flags endpoint returned status %d", response.StatusCode)
+	}
+
+	var flags map[string]*Flag
+	if err := requests.ReadResponseJSON(response, &flags); err != nil {
+		return err
+	}
+
+	p.flagsMutex.Lock()
+	p.flags = flags
+	p.flagsMutex.Unlock()
+
+	return nil
+}
+
+// Start implements the interface of tiny.Service.
+func (p *HTTPProvider) Start() error {
+	if err := p.Refresh(); err != nil {
+		log.Warn().Err(err).Msgf("Failed to fetch feature flags (%s)", p.url)
+	}
+
+	ticker := time.NewTicker(p.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopChannel:
+			return nil
+		case <-ticker.C:
+			if err := p.Refresh(); err != nil {
+				log.Warn().Err(err).Msgf("Failed to fetch feature flags (%s)", p.url)
+			}
+		}
+	}
+}
+
+// Stop implements the interface of tiny.Service.
+func (p *HTTPProvider) Stop() {
+	select {
+	case p.stopChannel <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,82 @@
+package tinyflags
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gookit/config/v2"
+)
+
+// StaticProvider is a Provider holding a fixed set of flags.
+type StaticProvider map[string]*Flag
+
+// Flag implements the interface of Provider.
+func (p StaticProvider) Flag(name string) (*Flag, bool) {
+	flag, ok := p[name]
+	return flag, ok
+}
+
+type configProvider struct {
+	prefix string
+}
+
+// ConfigProvider creates a Provider reading flags from the global configuration loaded by tiny.LoadConfig.
+// Flag "name" is read from key "prefix.name". The value might be either a boolean, a string, a rollout percentage
+// such as "25%", or a map with fields of Flag.
+func ConfigProvider(prefix string) Provider {
+	return &configProvider{
+		prefix: prefix,
+	}
+}
+
+func (p *configProvider) Flag(name string) (*Flag, bool) {
+	key := p.prefix + "." + name
+
+	value, ok := config.GetValue(key)
+	if !ok {
+		return nil, false
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return &Flag{Enabled: v}, true
+	case string:
+		return parseFlag(v), true
+	case map[string]any:
+		var flag Flag
+		if err := config.MapStruct(key, &flag); err != nil {
+			return nil, false
+		}
+
+		return &flag, true
+	default:
+		return nil, false
+	}
+}
+
+type envProvider struct {
+	prefix string
+}
+
+// EnvProvider creates a Provider reading flags from environment variables.
+// Flag "new-checkout" is read from variable "PREFIX_NEW_CHECKOUT". The value might be either a boolean, a string
+// or a rollout percentage such as "25%".
+func EnvProvider(prefix string) Provider {
+	return &envProvider{
+		prefix: prefix,
+	}
+}
+
+func (p *envProvider) Flag(name string) (*Flag, bool) {
+	envName := strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+	if p.prefix != "" {
+		envName = strings.ToUpper(p.prefix) + "_" + envName
+	}
+
+	value, ok := os.LookupEnv(envName)
+	if !ok {
+		return nil, false
+	}
+
+	return parseFlag(value), true
+}
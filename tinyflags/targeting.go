@@ -0,0 +1,96 @@
+package tinyflags
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mkorman9/tiny/tinygrpc"
+	"google.golang.org/grpc"
+)
+
+const targetLocalsKey = "tinyflags/target"
+
+type targetContextKey struct{}
+
+// WithTarget returns a copy of ctx carrying given target.
+func WithTarget(ctx context.Context, target *Target) context.Context {
+	return context.WithValue(ctx, targetContextKey{}, target)
+}
+
+// TargetFromContext returns a target attached to the context by WithTarget or by the gRPC interceptors.
+// Returns nil if no target is attached.
+func TargetFromContext(ctx context.Context) *Target {
+	if target, ok := ctx.Value(targetContextKey{}).(*Target); ok {
+		return target
+	}
+
+	return nil
+}
+
+// TargetMiddleware creates a Fiber middleware resolving a target for each request using given function.
+// The target can be later retrieved in handlers with TargetFromFiber.
+func TargetMiddleware(resolve func(c *fiber.Ctx) *Target) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if target := resolve(c); target != nil {
+			c.Locals(targetLocalsKey, target)
+			c.SetUserContext(WithTarget(c.UserContext(), target))
+		}
+
+		return c.Next()
+	}
+}
+
+// TargetFromFiber returns a target resolved by TargetMiddleware. Returns nil if no target is resolved.
+func TargetFromFiber(c *fiber.Ctx) *Target {
+	if target, ok := c.Locals(targetLocalsKey).(*Target); ok {
+		return target
+	}
+
+	return nil
+}
+
+// TargetInterceptors creates an option for tinygrpc.NewServer that resolves a target for each call using given
+// function. The target can be later retrieved in handlers with TargetFromContext.
+func TargetInterceptors(resolve func(ctx context.Context, method string) *Target) tinygrpc.ServerOpt {
+	return func(serverConfig *tinygrpc.ServerConfig) {
+		tinygrpc.UnaryInterceptor(func(
+			ctx context.Context,
+			req interface{},
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (interface{}, error) {
+			if target := resolve(ctx, info.FullMethod); target != nil {
+				ctx = WithTarget(ctx, target)
+			}
+
+			return handler(ctx, req)
+		})(serverConfig)
+
+		tinygrpc.StreamInterceptor(func(
+			srv interface{},
+			ss grpc.ServerStream,
+			info *grpc.StreamServerInfo,
+			handler grpc.StreamHandler,
+		) error {
+			target := resolve(ss.Context(), info.FullMethod)
+			if target == nil {
+				return handler(srv, ss)
+			}
+
+			return handler(srv, &targetServerStream{
+				ServerStream: ss,
+				ctx:          WithTarget(ss.Context(), target),
+			})
+		})(serverConfig)
+	}
+}
+
+type targetServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (s *targetServerStream) Context() context.Context {
+	return s.ctx
+}
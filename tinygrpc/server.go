@@ -4,13 +4,17 @@ import (
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"net"
+	"sync"
 )
 
 // Server is an object representing grpc.Server and implementing the tiny.Service interface.
+// It implements tiny.ReadinessNotifier - it becomes ready when it's bound to the address.
 type Server struct {
 	*grpc.Server
 
-	address string
+	address      string
+	readyChannel chan struct{}
+	readyOnce    sync.Once
 }
 
 // NewServer create new Server using global configuration and provided options.
@@ -32,8 +36,9 @@ func NewServer(address string, opts ...ServerOpt) *Server {
 	grpcOptions = append(grpcOptions, grpc.StreamInterceptor(chainStreamInterceptors(streamInterceptors...)))
 
 	return &Server{
-		Server:  grpc.NewServer(grpcOptions...),
-		address: address,
+		Server:       grpc.NewServer(grpcOptions...),
+		address:      address,
+		readyChannel: make(chan struct{}),
 	}
 }
 
@@ -46,6 +51,10 @@ func (s *Server) Start() error {
 
 	log.Info().Msgf("gRPC server started (%s)", s.address)
 
+	s.readyOnce.Do(func() {
+		close(s.readyChannel)
+	})
+
 	return s.Serve(listener)
 }
 
@@ -54,3 +63,13 @@ func (s *Server) Stop() {
 	s.GracefulStop()
 	log.Info().Msgf("gRPC server stopped (%s)", s.address)
 }
+
+// Ready implements the interface of tiny.ReadinessNotifier.
+func (s *Server) Ready() <-chan struct{} {
+	return s.readyChannel
+}
+
+// Address returns the address the server has been created with.
+func (s *Server) Address() string {
+	return s.address
+}
@@ -0,0 +1,123 @@
+package tinyhttp
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// AccessLogConfig holds a configuration for the access log middleware.
+type AccessLogConfig struct {
+	// SampleRate is a fraction of requests to log, between 0 and 1 (default: 1).
+	// Responses with status 500 or higher are always logged.
+	SampleRate float64
+
+	// ExcludePaths is a list of paths that should not be logged, for example health checks.
+	// Paths ending with "*" match any path with given prefix.
+	ExcludePaths []string
+
+	// Level is a level of the log entries (default: info).
+	// Responses with status 500 or higher are always logged with error level.
+	Level string
+}
+
+// NewAccessLogMiddleware creates a middleware emitting a structured log entry for each handled request,
+// including method, path, status, latency, size of the response, client IP and request ID.
+// It can be enabled for the whole server with ServerConfig.AccessLog.
+func NewAccessLogMiddleware(config ...*AccessLogConfig) fiber.Handler {
+	var providedConfig *AccessLogConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeAccessLogConfig(providedConfig)
+
+	defaultLevel, err := zerolog.ParseLevel(c.Level)
+	if err != nil {
+		defaultLevel = zerolog.InfoLevel
+	}
+
+	return func(ctx *fiber.Ctx) error {
+		if isPathExcluded(ctx.Path(), c.ExcludePaths) {
+			return ctx.Next()
+		}
+
+		startTime := time.Now()
+
+		if err := ctx.Next(); err != nil {
+			if handlerErr := ctx.App().ErrorHandler(ctx, err); handlerErr != nil {
+				_ = ctx.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := ctx.Response().StatusCode()
+		if status < fiber.StatusInternalServerError && c.SampleRate < 1 && rand.Float64() >= c.SampleRate {
+			return nil
+		}
+
+		level := defaultLevel
+		if status >= fiber.StatusInternalServerError {
+			level = zerolog.ErrorLevel
+		}
+
+		log.WithLevel(level).
+			Str("method", ctx.Method()).
+			Str("path", ctx.Path()).
+			Int("status", status).
+			Dur("latency", time.Since(startTime)).
+			Int("bytes", len(ctx.Response().Body())).
+			Str("ip", ctx.IP()).
+			Str("requestId", requestIDForLog(ctx)).
+			Msg("HTTP request")
+
+		return nil
+	}
+}
+
+func requestIDForLog(ctx *fiber.Ctx) string {
+	if id := RequestID(ctx); id != "" {
+		return id
+	}
+
+	return ctx.Get(fiber.HeaderXRequestID)
+}
+
+func isPathExcluded(path string, excludePaths []string) bool {
+	for _, excluded := range excludePaths {
+		if strings.HasSuffix(excluded, "*") {
+			if strings.HasPrefix(path, strings.TrimSuffix(excluded, "*")) {
+				return true
+			}
+		} else if path == excluded {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mergeAccessLogConfig(provided *AccessLogConfig) *AccessLogConfig {
+	config := &AccessLogConfig{
+		SampleRate: 1,
+		Level:      "info",
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.SampleRate > 0 && provided.SampleRate < 1 {
+		config.SampleRate = provided.SampleRate
+	}
+	if provided.ExcludePaths != nil {
+		config.ExcludePaths = provided.ExcludePaths
+	}
+	if provided.Level != "" {
+		config.Level = provided.Level
+	}
+
+	return config
+}
@@ -0,0 +1,87 @@
+package tinyhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func captureAccessLog(t *testing.T, config *AccessLogConfig, paths ...string) []map[string]any {
+	var output bytes.Buffer
+	originalLogger := log.Logger
+	log.Logger = zerolog.New(&output)
+	defer func() {
+		log.Logger = originalLogger
+	}()
+
+	server := NewServer("address", &ServerConfig{AccessLog: config})
+	server.OnPanic(func(c *fiber.Ctx, recovered any) {})
+	server.Get("/ok", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	server.Get("/panic", func(c *fiber.Ctx) error {
+		panic("failure")
+	})
+	server.Get("/health/live", func(c *fiber.Ctx) error {
+		return c.SendString("live")
+	})
+
+	for _, path := range paths {
+		req, _ := http.NewRequest("GET", path, nil)
+		_, err := server.App.Test(req, -1)
+		assert.NoError(t, err)
+	}
+
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		var entry map[string]any
+		if json.Unmarshal([]byte(line), &entry) == nil && entry["message"] == "HTTP request" {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+func TestAccessLogFields(t *testing.T) {
+	// when
+	entries := captureAccessLog(t, &AccessLogConfig{}, "/ok", "/panic")
+
+	// then
+	assert.Len(t, entries, 2, "both requests should be logged")
+	assert.Equal(t, "/ok", entries[0]["path"])
+	assert.Equal(t, "GET", entries[0]["method"])
+	assert.EqualValues(t, fiber.StatusOK, entries[0]["status"])
+	assert.EqualValues(t, 2, entries[0]["bytes"])
+	assert.Contains(t, entries[0], "latency", "latency should be logged")
+	assert.NotEmpty(t, entries[0]["requestId"], "request ID should be logged")
+	assert.Equal(t, "info", entries[0]["level"])
+
+	assert.Equal(t, "/panic", entries[1]["path"], "request ending with panic should be logged")
+	assert.EqualValues(t, fiber.StatusInternalServerError, entries[1]["status"])
+	assert.Equal(t, "error", entries[1]["level"])
+}
+
+func TestAccessLogExcludePaths(t *testing.T) {
+	// when
+	entries := captureAccessLog(t, &AccessLogConfig{ExcludePaths: []string{"/health*"}}, "/health/live", "/ok")
+
+	// then
+	assert.Len(t, entries, 1, "excluded path should not be logged")
+	assert.Equal(t, "/ok", entries[0]["path"])
+}
+
+func TestAccessLogSamplingBypassedByServerErrors(t *testing.T) {
+	// when
+	entries := captureAccessLog(t, &AccessLogConfig{SampleRate: 1e-12}, "/ok", "/ok", "/panic")
+
+	// then
+	assert.Len(t, entries, 1, "only server error should be logged")
+	assert.Equal(t, "/panic", entries[0]["path"])
+}
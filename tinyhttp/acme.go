@@ -0,0 +1,99 @@
+package tinyhttp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// ACMEConfig holds a configuration of automatic TLS certificates, issued by Let's Encrypt or other ACME provider.
+type ACMEConfig struct {
+	// Domains is a list of domains the certificates can be issued for.
+	Domains []string
+
+	// CacheDir is a directory the certificates are stored in (default: "certs").
+	CacheDir string
+
+	// Email is an optional contact email, used by the provider to notify about problems with the certificates.
+	Email string
+
+	// DirectoryURL is an address of ACME directory endpoint (default: Let's Encrypt production endpoint).
+	DirectoryURL string
+
+	// HTTPChallengeAddress is an optional address of a plain HTTP server, answering HTTP-01 challenges
+	// and redirecting other requests to HTTPS, for example ":80". When it's empty, only TLS-ALPN-01
+	// challenges are answered, by the main server.
+	HTTPChallengeAddress string
+}
+
+type acmeServer struct {
+	manager         *autocert.Manager
+	challengeServer *http.Server
+}
+
+func newACMEServer(config *ACMEConfig) *acmeServer {
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(config.Domains...),
+		Cache:      autocert.DirCache(config.CacheDir),
+		Email:      config.Email,
+	}
+
+	if config.DirectoryURL != "" {
+		manager.Client = &acme.Client{DirectoryURL: config.DirectoryURL}
+	}
+
+	server := &acmeServer{
+		manager: manager,
+	}
+
+	if config.HTTPChallengeAddress != "" {
+		server.challengeServer = &http.Server{
+			Addr:              config.HTTPChallengeAddress,
+			Handler:           manager.HTTPHandler(nil),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+	}
+
+	return server
+}
+
+// configureTLS makes the TLS configuration obtain certificates from ACME provider.
+func (a *acmeServer) configureTLS(tlsConfig *tls.Config, tlsHandler *fiber.TLSHandler) {
+	tlsConfig.GetCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		_, _ = tlsHandler.GetClientInfo(hello)
+		return a.manager.GetCertificate(hello)
+	}
+	tlsConfig.NextProtos = append(tlsConfig.NextProtos, acme.ALPNProto)
+}
+
+func (a *acmeServer) start() {
+	if a.challengeServer == nil {
+		return
+	}
+
+	go func() {
+		err := a.challengeServer.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Err(err).Msgf("Error serving ACME challenges (%s)", a.challengeServer.Addr)
+		}
+	}()
+}
+
+func (a *acmeServer) stop(timeout time.Duration) {
+	if a.challengeServer == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	_ = a.challengeServer.Shutdown(ctx)
+}
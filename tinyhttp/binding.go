@@ -0,0 +1,218 @@
+package tinyhttp
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errBindTarget = errors.New("binding target must be a pointer to struct")
+
+// BindQuery tries to parse the query string into the fields tagged with `query` and validate resulting object
+// using the DefaultValidator. Slice fields accept either repeated keys or a single comma-separated value.
+func BindQuery(c *fiber.Ctx, out any) []ValidationError {
+	return bind(c, out, "query")
+}
+
+// BindHeaders tries to parse the request headers into the fields tagged with `header` and validate resulting object
+// using the DefaultValidator.
+func BindHeaders(c *fiber.Ctx, out any) []ValidationError {
+	return bind(c, out, "header")
+}
+
+// BindURI tries to parse the route parameters into the fields tagged with `uri` and validate resulting object
+// using the DefaultValidator.
+func BindURI(c *fiber.Ctx, out any) []ValidationError {
+	return bind(c, out, "uri")
+}
+
+// Bind tries to parse the route parameters, query string, headers and the body (if present) into a single object
+// and validate it using the DefaultValidator. The validation is performed once, after all the sources are parsed.
+func Bind(c *fiber.Ctx, out any) []ValidationError {
+	sources := []string{"uri", "query", "header"}
+	if len(c.Body()) > 0 {
+		sources = append(sources, "body")
+	}
+
+	return bind(c, out, sources...)
+}
+
+func bind(c *fiber.Ctx, out any, sources ...string) []ValidationError {
+	for _, source := range sources {
+		var err error
+
+		switch source {
+		case "body":
+			err = c.BodyParser(out)
+		case "query":
+			err = decodeTagged(out, "query", func(name string) []string {
+				var values []string
+				for _, v := range c.Context().QueryArgs().PeekMulti(name) {
+					values = append(values, string(v))
+				}
+				return values
+			})
+		case "header":
+			err = decodeTagged(out, "header", func(name string) []string {
+				if value := c.Get(name); value != "" {
+					return []string{value}
+				}
+				return nil
+			})
+		case "uri":
+			err = decodeTagged(out, "uri", func(name string) []string {
+				if value := c.Params(name); value != "" {
+					return []string{value}
+				}
+				return nil
+			})
+		}
+
+		if err != nil {
+			return []ValidationError{
+				{Field: source, Tag: "format"},
+			}
+		}
+	}
+
+	if err := DefaultValidator.Struct(out); err != nil {
+		return ExtractValidatorErrors(err)
+	}
+
+	return nil
+}
+
+func decodeTagged(out any, tag string, lookup func(name string) []string) error {
+	value := reflect.ValueOf(out)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return errBindTarget
+	}
+
+	return decodeStruct(value.Elem(), tag, lookup)
+}
+
+func decodeStruct(value reflect.Value, tag string, lookup func(name string) []string) error {
+	valueType := value.Type()
+
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := resolveTag(field, tag)
+		if name == "" {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				if err := decodeStruct(value.Field(i), tag, lookup); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		values := lookup(name)
+		if len(values) == 0 {
+			continue
+		}
+
+		if err := setField(value.Field(i), values); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func setField(field reflect.Value, values []string) error {
+	if field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8 {
+		if len(values) == 1 {
+			values = splitValue(values[0])
+		}
+
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, v := range values {
+			if err := setValue(slice.Index(i), v); err != nil {
+				return err
+			}
+		}
+
+		field.Set(slice)
+		return nil
+	}
+
+	return setValue(field, values[0])
+}
+
+func setValue(field reflect.Value, value string) error {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+
+		return setValue(field.Elem(), value)
+	}
+
+	if unmarshaler, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(value))
+	}
+
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		field.SetBytes([]byte(value))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
+
+func splitValue(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		values = append(values, strings.TrimSpace(v))
+	}
+
+	return values
+}
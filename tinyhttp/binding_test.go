@@ -0,0 +1,51 @@
+package tinyhttp
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+type bindingRequest struct {
+	ID     int      `uri:"id" validate:"required"`
+	Limit  int      `query:"limit" validate:"required,max=100"`
+	Tags   []string `query:"tags"`
+	Client string   `header:"X-Client"`
+}
+
+func TestBind(t *testing.T) {
+	// given
+	var bound bindingRequest
+	var errors []ValidationError
+
+	app := fiber.New()
+	app.Get("/items/:id", func(c *fiber.Ctx) error {
+		bound = bindingRequest{}
+		errors = Bind(c, &bound)
+		return nil
+	})
+
+	// when
+	req, _ := http.NewRequest("GET", "/items/5?limit=10&tags=a,b", nil)
+	req.Header.Set("X-Client", "test")
+	_, _ = app.Test(req, -1)
+
+	// then
+	assert.Nil(t, errors, "request should be valid")
+	assert.Equal(t, bindingRequest{ID: 5, Limit: 10, Tags: []string{"a", "b"}, Client: "test"}, bound)
+
+	// when
+	req, _ = http.NewRequest("GET", "/items/5?limit=1000", nil)
+	_, _ = app.Test(req, -1)
+
+	// then
+	assert.Equal(t, []ValidationError{{Field: "limit", Tag: "max", Err: errors[0].Err}}, errors)
+
+	// when
+	req, _ = http.NewRequest("GET", "/items/x?limit=10", nil)
+	_, _ = app.Test(req, -1)
+
+	// then
+	assert.Equal(t, []ValidationError{{Field: "uri", Tag: "format"}}, errors)
+}
@@ -0,0 +1,165 @@
+package tinyhttp
+
+import (
+	"crypto/tls"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// TLSCertificate is a certificate served to the clients requesting one of the given hosts (using SNI).
+type TLSCertificate struct {
+	// Hosts is a list of host names the certificate is served for. Wildcards, such as "*.example.com", are supported.
+	Hosts []string
+
+	// Cert is a path to the certificate file.
+	Cert string
+
+	// Key is a path to the key file.
+	Key string
+}
+
+type loadedCertificates struct {
+	byHost       map[string]*tls.Certificate
+	defaultCert  *tls.Certificate
+	modification map[string]time.Time
+}
+
+// certificateStore selects certificates by the host name requested by the client and allows to reload them.
+type certificateStore struct {
+	defaultCert  *TLSCertificate
+	certificates []TLSCertificate
+	loaded       atomic.Pointer[loadedCertificates]
+	stopChannel  chan struct{}
+	stopOnce     sync.Once
+}
+
+func newCertificateStore(defaultCert *TLSCertificate, certificates []TLSCertificate) (*certificateStore, error) {
+	store := &certificateStore{
+		defaultCert:  defaultCert,
+		certificates: certificates,
+		stopChannel:  make(chan struct{}),
+	}
+
+	if err := store.reload(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (c *certificateStore) getCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	loaded := c.loaded.Load()
+	serverName := strings.ToLower(strings.TrimSuffix(hello.ServerName, "."))
+
+	if cert, ok := loaded.byHost[serverName]; ok {
+		return cert, nil
+	}
+
+	if i := strings.IndexByte(serverName, '.'); i > 0 {
+		if cert, ok := loaded.byHost["*"+serverName[i:]]; ok {
+			return cert, nil
+		}
+	}
+
+	if loaded.defaultCert != nil {
+		return loaded.defaultCert, nil
+	}
+
+	return nil, errors.New("no certificate for host " + serverName)
+}
+
+func (c *certificateStore) reload() error {
+	loaded := &loadedCertificates{
+		byHost:       map[string]*tls.Certificate{},
+		modification: map[string]time.Time{},
+	}
+
+	load := func(certificate *TLSCertificate) (*tls.Certificate, error) {
+		// modification times are read before loading, so the files replaced in the meantime are reloaded later
+		for _, file := range []string{certificate.Cert, certificate.Key} {
+			if info, err := os.Stat(file); err == nil {
+				loaded.modification[file] = info.ModTime()
+			}
+		}
+
+		cert, err := tls.LoadX509KeyPair(certificate.Cert, certificate.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		return &cert, nil
+	}
+
+	if c.defaultCert != nil {
+		cert, err := load(c.defaultCert)
+		if err != nil {
+			return err
+		}
+
+		loaded.defaultCert = cert
+	}
+
+	for i := range c.certificates {
+		cert, err := load(&c.certificates[i])
+		if err != nil {
+			return err
+		}
+
+		for _, host := range c.certificates[i].Hosts {
+			loaded.byHost[strings.ToLower(host)] = cert
+		}
+
+		if loaded.defaultCert == nil {
+			loaded.defaultCert = cert
+		}
+	}
+
+	c.loaded.Store(loaded)
+	return nil
+}
+
+// modified checks whether any of the files has been modified since it was loaded.
+func (c *certificateStore) modified() bool {
+	for file, modification := range c.loaded.Load().modification {
+		info, err := os.Stat(file)
+		if err == nil && !info.ModTime().Equal(modification) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *certificateStore) watch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopChannel:
+			return
+		case <-ticker.C:
+			if !c.modified() {
+				continue
+			}
+
+			if err := c.reload(); err != nil {
+				log.Error().Err(err).Msg("Failed to reload TLS certificates")
+			} else {
+				log.Info().Msg("TLS certificates reloaded")
+			}
+		}
+	}
+}
+
+func (c *certificateStore) stop() {
+	c.stopOnce.Do(func() {
+		close(c.stopChannel)
+	})
+}
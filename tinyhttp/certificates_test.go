@@ -0,0 +1,92 @@
+package tinyhttp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTestCertificate(t *testing.T, name string, hosts ...string) *TLSCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     hosts,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certificate := &TLSCertificate{
+		Hosts: hosts,
+		Cert:  filepath.Join(dir, name+".crt"),
+		Key:   filepath.Join(dir, name+".key"),
+	}
+
+	_ = os.WriteFile(certificate.Cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	_ = os.WriteFile(certificate.Key, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+
+	return certificate
+}
+
+func TestCertificateSelection(t *testing.T) {
+	// given
+	defaultCert := writeTestCertificate(t, "default", "localhost")
+	exact := writeTestCertificate(t, "exact", "api.example.com")
+	wildcard := writeTestCertificate(t, "wildcard", "*.example.com")
+
+	store, err := newCertificateStore(defaultCert, []TLSCertificate{*exact, *wildcard})
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+
+	// when
+	selected := func(serverName string) string {
+		cert, err := store.getCertificate(&tls.ClientHelloInfo{ServerName: serverName})
+		if err != nil {
+			return ""
+		}
+
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return ""
+		}
+
+		return leaf.Subject.CommonName
+	}
+
+	// then
+	assert.Equal(t, "exact", selected("api.example.com"), "exact match should be preferred")
+	assert.Equal(t, "exact", selected("API.example.com."), "host should be normalized")
+	assert.Equal(t, "wildcard", selected("www.example.com"), "wildcard should match a subdomain")
+	assert.Equal(t, "default", selected("a.b.example.com"), "wildcard should match only one label")
+	assert.Equal(t, "default", selected("other.org"), "default certificate should be used for unknown hosts")
+	assert.Equal(t, "default", selected(""), "default certificate should be used without SNI")
+}
@@ -0,0 +1,135 @@
+package tinyhttp
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// CompressionConfig holds a configuration for the compression middleware.
+type CompressionConfig struct {
+	// GzipLevel is a compression level used for gzip, between 1 and 9 (default: 6).
+	GzipLevel int
+
+	// BrotliLevel is a compression level used for brotli, between 1 and 11 (default: 4).
+	BrotliLevel int
+
+	// DisableBrotli disables brotli compression, so only gzip is used (default: false).
+	DisableBrotli bool
+
+	// MinSize is a minimal size of the response body to be compressed, in bytes (default: 1024).
+	MinSize int
+
+	// ContentTypes is a list of compressed content types. Entries ending with "/*" match all subtypes
+	// (default: "text/*", "application/json", "application/javascript", "application/xml", "image/svg+xml").
+	ContentTypes []string
+}
+
+// NewCompressionMiddleware creates a middleware compressing response bodies with brotli or gzip,
+// depending on the Accept-Encoding header of the request. Partial content responses (206) and responses
+// that already have Content-Encoding set are not compressed. It can be enabled for the whole server
+// with ServerConfig.Compression.
+func NewCompressionMiddleware(config ...*CompressionConfig) fiber.Handler {
+	var providedConfig *CompressionConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeCompressionConfig(providedConfig)
+
+	return func(ctx *fiber.Ctx) error {
+		if err := ctx.Next(); err != nil {
+			return err
+		}
+
+		response := ctx.Response()
+		if response.IsBodyStream() ||
+			response.StatusCode() == fiber.StatusPartialContent ||
+			len(response.Header.Peek(fiber.HeaderContentEncoding)) > 0 ||
+			len(response.Body()) < c.MinSize ||
+			!isCompressedContentType(string(response.Header.ContentType()), c.ContentTypes) {
+			return nil
+		}
+
+		ctx.Vary(fiber.HeaderAcceptEncoding)
+
+		acceptEncoding := ctx.Get(fiber.HeaderAcceptEncoding)
+		switch {
+		case !c.DisableBrotli && acceptsEncoding(acceptEncoding, "br"):
+			response.SetBodyRaw(fasthttp.AppendBrotliBytesLevel(nil, response.Body(), c.BrotliLevel))
+			ctx.Set(fiber.HeaderContentEncoding, "br")
+		case acceptsEncoding(acceptEncoding, "gzip"):
+			response.SetBodyRaw(fasthttp.AppendGzipBytesLevel(nil, response.Body(), c.GzipLevel))
+			ctx.Set(fiber.HeaderContentEncoding, "gzip")
+		}
+
+		return nil
+	}
+}
+
+func acceptsEncoding(acceptEncoding, encoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(name), encoding) {
+			continue
+		}
+
+		return strings.ReplaceAll(strings.TrimSpace(params), " ", "") != "q=0"
+	}
+
+	return false
+}
+
+func isCompressedContentType(contentType string, contentTypes []string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+
+	for _, pattern := range contentTypes {
+		if strings.HasSuffix(pattern, "/*") {
+			if strings.HasPrefix(mediaType, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+		} else if mediaType == pattern {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mergeCompressionConfig(provided *CompressionConfig) *CompressionConfig {
+	config := &CompressionConfig{
+		GzipLevel:   fasthttp.CompressDefaultCompression,
+		BrotliLevel: fasthttp.CompressBrotliDefaultCompression,
+		MinSize:     1024,
+		ContentTypes: []string{
+			"text/*",
+			"application/json",
+			"application/javascript",
+			"application/xml",
+			"image/svg+xml",
+		},
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.GzipLevel > 0 {
+		config.GzipLevel = provided.GzipLevel
+	}
+	if provided.BrotliLevel > 0 {
+		config.BrotliLevel = provided.BrotliLevel
+	}
+	if provided.DisableBrotli {
+		config.DisableBrotli = true
+	}
+	if provided.MinSize > 0 {
+		config.MinSize = provided.MinSize
+	}
+	if provided.ContentTypes != nil {
+		config.ContentTypes = provided.ContentTypes
+	}
+
+	return config
+}
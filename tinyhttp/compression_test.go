@@ -0,0 +1,55 @@
+package tinyhttp
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCompression(t *testing.T) {
+	// given
+	large := strings.Repeat("compressible text ", 100)
+
+	server := NewServer("address", &ServerConfig{Compression: &CompressionConfig{}})
+	server.Get("/large", func(c *fiber.Ctx) error {
+		return c.SendString(large)
+	})
+	server.Get("/small", func(c *fiber.Ctx) error {
+		return c.SendString("small")
+	})
+	server.Get("/partial", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentRange, "bytes 0-1799/5000")
+		return c.Status(fiber.StatusPartialContent).SendString(large)
+	})
+	server.Get("/encoded", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentEncoding, "identity")
+		return c.SendString(large)
+	})
+
+	// when
+	encoding := func(path, acceptEncoding string) string {
+		req, _ := http.NewRequest("GET", path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set(fiber.HeaderAcceptEncoding, acceptEncoding)
+		}
+
+		response, err := server.App.Test(req, -1)
+		if err != nil {
+			return "error"
+		}
+		defer response.Body.Close()
+
+		return response.Header.Get(fiber.HeaderContentEncoding)
+	}
+
+	// then
+	assert.Equal(t, "br", encoding("/large", "gzip, br"), "brotli should be preferred")
+	assert.Equal(t, "gzip", encoding("/large", "gzip, br;q=0"), "rejected encoding should not be used")
+	assert.Equal(t, "gzip", encoding("/large", "gzip"), "gzip should be used")
+	assert.Equal(t, "", encoding("/large", ""), "response should not be compressed without Accept-Encoding")
+	assert.Equal(t, "", encoding("/small", "gzip, br"), "response below the threshold should not be compressed")
+	assert.Equal(t, "", encoding("/partial", "gzip, br"), "partial content should not be compressed")
+	assert.Equal(t, "identity", encoding("/encoded", "gzip, br"), "encoded response should not be compressed again")
+}
@@ -3,6 +3,7 @@ package tinyhttp
 import (
 	"crypto/tls"
 	"github.com/gofiber/fiber/v2"
+	"os"
 	"time"
 )
 
@@ -11,6 +12,21 @@ type ServerConfig struct {
 	// Network is a network type for the listener (default: "tcp").
 	Network string
 
+	// SocketMode is a file mode set for the socket file, when Network is "unix" (default: 0660).
+	// Stale socket file left at the address is removed before binding.
+	SocketMode os.FileMode
+
+	// SocketActivation makes the server use the socket passed by systemd (LISTEN_FDS) instead of binding
+	// to the address (default: false).
+	SocketActivation bool
+
+	// SocketName selects the socket passed by systemd by its FileDescriptorName= (default: "", the first socket).
+	SocketName string
+
+	// ReusePort sets SO_REUSEPORT option on the listening socket, so a new instance of the application can bind
+	// to the same port before the old one is stopped (default: false).
+	ReusePort bool
+
 	// SecurityHeaders defines whether to include HTTP security headers to all responses or not (default: true).
 	SecurityHeaders bool
 
@@ -23,9 +39,21 @@ type ServerConfig struct {
 	// TLSKey is a path to TLS key to use. When specified with TLSCert - enables TLS mode.
 	TLSKey string
 
+	// TLSCertificates is a list of certificates selected by the host name requested by the client (using SNI).
+	// Certificate specified with TLSCert and TLSKey (or the first one on this list) is served to other clients.
+	TLSCertificates []TLSCertificate
+
+	// TLSReloadInterval is an interval of checking whether the certificate files have changed
+	// and reloading them (default: 0, disabled).
+	TLSReloadInterval time.Duration
+
 	// TLSConfig is an optional TLS configuration to pass when using TLS mode.
 	TLSConfig *tls.Config
 
+	// ACME enables automatic issuance and renewal of TLS certificates with given configuration.
+	// It takes precedence over TLSCert and TLSKey (default: nil, disabled).
+	ACME *ACMEConfig
+
 	// ReadTimeout is a timeout used when creating underlying http server (default: 5s).
 	ReadTimeout time.Duration
 
@@ -52,6 +80,15 @@ type ServerConfig struct {
 	// Concurrency specifies a maximum number of concurrent connections (default: 256 * 1024).
 	Concurrency int
 
+	// MaxConnsPerIP specifies a maximum number of concurrent connections from a single IP address.
+	// Connections above the limit are closed right after being accepted. The limit applies to the address
+	// of the peer, so clients behind the same trusted proxy share it (default: 0, unlimited).
+	MaxConnsPerIP int
+
+	// MaxRequestsPerConn specifies a maximum number of requests served on a single keep-alive connection,
+	// before it's closed (default: 0, unlimited).
+	MaxRequestsPerConn int
+
 	// BodyLimit specifies a maximum allowed size for a request body (default: 4 * 1024 * 1024).
 	BodyLimit int
 
@@ -61,6 +98,27 @@ type ServerConfig struct {
 	// WriteBufferSize specifies a per-connection buffer size for responses (default: 4096).
 	WriteBufferSize int
 
+	// DisableRequestID disables assigning IDs to requests (default: false).
+	DisableRequestID bool
+
+	// AccessLog enables logging of each handled request with given configuration (default: nil, disabled).
+	AccessLog *AccessLogConfig
+
+	// RateLimit enables limiting the rate of requests made by each client with given configuration
+	// (default: nil, disabled).
+	RateLimit *RateLimitConfig
+
+	// Compression enables compression of response bodies with given configuration (default: nil, disabled).
+	Compression *CompressionConfig
+
+	// EnvelopeErrors makes the default error handler render errors (including panics) in the same format
+	// as Error does (default: false).
+	EnvelopeErrors bool
+
+	// ProblemDetails makes Error render errors as RFC 7807 problem details instead of the Envelope.
+	// It implies EnvelopeErrors (default: false).
+	ProblemDetails bool
+
 	// FiberOpt allows to specify custom function that will operate directly on *fiber.Config.
 	FiberOpt func(*fiber.Config)
 }
@@ -68,6 +126,7 @@ type ServerConfig struct {
 func mergeServerConfig(provided *ServerConfig) *ServerConfig {
 	config := &ServerConfig{
 		Network:         "tcp",
+		SocketMode:      0660,
 		SecurityHeaders: true,
 		ShutdownTimeout: 5 * time.Second,
 		TLSConfig:       &tls.Config{},
@@ -96,6 +155,18 @@ func mergeServerConfig(provided *ServerConfig) *ServerConfig {
 	if provided.Network != "" {
 		config.Network = provided.Network
 	}
+	if provided.SocketMode != 0 {
+		config.SocketMode = provided.SocketMode
+	}
+	if provided.SocketActivation {
+		config.SocketActivation = true
+	}
+	if provided.SocketName != "" {
+		config.SocketName = provided.SocketName
+	}
+	if provided.ReusePort {
+		config.ReusePort = true
+	}
 	if provided.SecurityHeaders {
 		config.SecurityHeaders = true
 	}
@@ -108,9 +179,18 @@ func mergeServerConfig(provided *ServerConfig) *ServerConfig {
 	if provided.TLSKey != "" {
 		config.TLSKey = provided.TLSKey
 	}
+	if provided.TLSCertificates != nil {
+		config.TLSCertificates = provided.TLSCertificates
+	}
+	if provided.TLSReloadInterval > 0 {
+		config.TLSReloadInterval = provided.TLSReloadInterval
+	}
 	if provided.TLSConfig != nil {
 		config.TLSConfig = provided.TLSConfig
 	}
+	if provided.ACME != nil {
+		config.ACME = mergeACMEConfig(provided.ACME)
+	}
 	if provided.ReadTimeout > 0 {
 		config.ReadTimeout = provided.ReadTimeout
 	}
@@ -135,6 +215,12 @@ func mergeServerConfig(provided *ServerConfig) *ServerConfig {
 	if provided.Concurrency > 0 {
 		config.Concurrency = provided.Concurrency
 	}
+	if provided.MaxConnsPerIP > 0 {
+		config.MaxConnsPerIP = provided.MaxConnsPerIP
+	}
+	if provided.MaxRequestsPerConn > 0 {
+		config.MaxRequestsPerConn = provided.MaxRequestsPerConn
+	}
 	if provided.BodyLimit > 0 {
 		config.BodyLimit = provided.BodyLimit
 	}
@@ -144,9 +230,44 @@ func mergeServerConfig(provided *ServerConfig) *ServerConfig {
 	if provided.WriteBufferSize > 0 {
 		config.WriteBufferSize = provided.WriteBufferSize
 	}
+	if provided.DisableRequestID {
+		config.DisableRequestID = true
+	}
+	if provided.AccessLog != nil {
+		config.AccessLog = provided.AccessLog
+	}
+	if provided.RateLimit != nil {
+		config.RateLimit = provided.RateLimit
+	}
+	if provided.Compression != nil {
+		config.Compression = provided.Compression
+	}
+	if provided.EnvelopeErrors {
+		config.EnvelopeErrors = true
+	}
+	if provided.ProblemDetails {
+		config.ProblemDetails = true
+		config.EnvelopeErrors = true
+	}
 	if provided.FiberOpt != nil {
 		config.FiberOpt = provided.FiberOpt
 	}
 
 	return config
 }
+
+func mergeACMEConfig(provided *ACMEConfig) *ACMEConfig {
+	config := &ACMEConfig{
+		Domains:              provided.Domains,
+		CacheDir:             "certs",
+		Email:                provided.Email,
+		DirectoryURL:         provided.DirectoryURL,
+		HTTPChallengeAddress: provided.HTTPChallengeAddress,
+	}
+
+	if provided.CacheDir != "" {
+		config.CacheDir = provided.CacheDir
+	}
+
+	return config
+}
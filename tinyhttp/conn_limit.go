@@ -0,0 +1,86 @@
+package tinyhttp
+
+import (
+	"github.com/rs/zerolog/log"
+	"net"
+	"sync"
+)
+
+// connLimitListener is a net.Listener limiting the number of concurrent connections from a single IP address.
+type connLimitListener struct {
+	net.Listener
+
+	limit  int
+	lock   sync.Mutex
+	counts map[string]int
+}
+
+type limitedConn struct {
+	net.Conn
+
+	closeOnce sync.Once
+	release   func()
+}
+
+func newConnLimitListener(listener net.Listener, limit int) *connLimitListener {
+	return &connLimitListener{
+		Listener: listener,
+		limit:    limit,
+		counts:   map[string]int{},
+	}
+}
+
+func (l *connLimitListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		addr, ok := conn.RemoteAddr().(*net.TCPAddr)
+		if !ok {
+			return conn, nil
+		}
+
+		ip := addr.IP.String()
+		if !l.acquire(ip) {
+			log.Debug().Msgf("Connection limit per IP exceeded by %s", ip)
+			_ = conn.Close()
+			continue
+		}
+
+		return &limitedConn{
+			Conn: conn,
+			release: func() {
+				l.release(ip)
+			},
+		}, nil
+	}
+}
+
+func (l *connLimitListener) acquire(ip string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.counts[ip] >= l.limit {
+		return false
+	}
+
+	l.counts[ip]++
+	return true
+}
+
+func (l *connLimitListener) release(ip string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.counts[ip]--
+	if l.counts[ip] <= 0 {
+		delete(l.counts, ip)
+	}
+}
+
+func (c *limitedConn) Close() error {
+	c.closeOnce.Do(c.release)
+	return c.Conn.Close()
+}
@@ -0,0 +1,99 @@
+package tinyhttp
+
+import (
+	"crypto/tls"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestConnLimitListener(t *testing.T) {
+	// given
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		assert.Error(t, err)
+		return
+	}
+
+	listener := newConnLimitListener(tcpListener, 1)
+	defer listener.Close()
+
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	// when
+	first, _ := net.Dial("tcp", tcpListener.Addr().String())
+	defer first.Close()
+	firstAccepted := <-accepted
+
+	second, _ := net.Dial("tcp", tcpListener.Addr().String())
+	defer second.Close()
+
+	_ = second.SetReadDeadline(time.Now().Add(time.Second))
+	_, secondErr := second.Read(make([]byte, 1))
+
+	_ = firstAccepted.Close()
+	third, _ := net.Dial("tcp", tcpListener.Addr().String())
+	defer third.Close()
+
+	// then
+	assert.Error(t, secondErr, "connection above the limit should be closed")
+	select {
+	case <-accepted:
+	case <-time.After(time.Second):
+		assert.Fail(t, "connection should be accepted after the previous one is closed")
+	}
+}
+
+func TestConnLimitWithTLS(t *testing.T) {
+	// given
+	certificate := writeTestCertificate(t, "server", "localhost")
+
+	server := NewServer("127.0.0.1:0", &ServerConfig{
+		TLSCert:       certificate.Cert,
+		TLSKey:        certificate.Key,
+		MaxConnsPerIP: 2,
+	})
+	server.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(c.Protocol())
+	})
+
+	go func() {
+		_ = server.Start()
+	}()
+	defer server.Stop()
+	<-server.Ready()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	defer client.CloseIdleConnections()
+
+	// when
+	response, err := client.Get(fmt.Sprintf("https://127.0.0.1:%d/", server.Port()))
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(response.Body)
+
+	// then
+	assert.Equal(t, "https", string(body), "request should be recognized as TLS")
+}
@@ -0,0 +1,133 @@
+package tinyhttp
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"strings"
+)
+
+const (
+	// MIMEApplicationProblemJSON is a content type of RFC 7807 problem details.
+	MIMEApplicationProblemJSON = "application/problem+json"
+
+	problemDetailsLocal = "tinyhttp.problemDetails"
+)
+
+// Envelope is a standard body of JSON responses. Exactly one of the fields is set.
+type Envelope struct {
+	// Data holds a payload of successful response.
+	Data any `json:"data,omitempty"`
+
+	// Error describes an error that occurred while handling the request.
+	Error *EnvelopeError `json:"error,omitempty"`
+}
+
+// EnvelopeError describes an error returned in the Envelope.
+type EnvelopeError struct {
+	// Code is a machine-readable code of the error, for example "not_found".
+	Code string `json:"code"`
+
+	// Message is a human-readable description of the error.
+	Message string `json:"message"`
+
+	// RequestID is an ID of the request, if the request ID middleware is enabled.
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// ProblemDetails is an error response body in the format defined by RFC 7807.
+type ProblemDetails struct {
+	// Type is a URI reference identifying the problem type.
+	Type string `json:"type"`
+
+	// Title is a short summary of the problem type.
+	Title string `json:"title"`
+
+	// Status is an HTTP status code of the response.
+	Status int `json:"status"`
+
+	// Detail is a human-readable explanation specific to this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+
+	// Instance is a URI reference identifying this occurrence of the problem.
+	Instance string `json:"instance,omitempty"`
+
+	// Code is a machine-readable code of the error, for example "not_found".
+	Code string `json:"code,omitempty"`
+
+	// RequestID is an ID of the request, if the request ID middleware is enabled.
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// OK responds with 200 OK and given data wrapped in the Envelope.
+func OK(c *fiber.Ctx, data any) error {
+	return c.Status(fiber.StatusOK).JSON(&Envelope{Data: data})
+}
+
+// Created responds with 201 Created and given data wrapped in the Envelope.
+func Created(c *fiber.Ctx, data any) error {
+	return c.Status(fiber.StatusCreated).JSON(&Envelope{Data: data})
+}
+
+// NoContent responds with 204 No Content and empty body.
+func NoContent(c *fiber.Ctx) error {
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Error responds with given status and an error wrapped in the Envelope. If the server is configured with
+// ServerConfig.ProblemDetails, the error is rendered as RFC 7807 problem details instead.
+// If code is empty, it's derived from the status, for example "not_found" for 404.
+func Error(c *fiber.Ctx, status int, code string, message string) error {
+	if code == "" {
+		code = statusCode(status)
+	}
+
+	if problemDetails, _ := GetLocal[bool](c, problemDetailsLocal); problemDetails {
+		c.Status(status)
+		c.Set(fiber.HeaderContentType, MIMEApplicationProblemJSON)
+
+		body, err := c.App().Config().JSONEncoder(&ProblemDetails{
+			Type:      "about:blank",
+			Title:     http.StatusText(status),
+			Status:    status,
+			Detail:    message,
+			Instance:  c.OriginalURL(),
+			Code:      code,
+			RequestID: RequestID(c),
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Send(body)
+	}
+
+	return c.Status(status).JSON(&Envelope{
+		Error: &EnvelopeError{
+			Code:      code,
+			Message:   message,
+			RequestID: RequestID(c),
+		},
+	})
+}
+
+// ErrorFrom responds with an error wrapped in the Envelope, like Error, based on the given error.
+// Status and message of *fiber.Error are preserved, other errors result in 500 with a generic message,
+// so that internal details are not leaked to the client.
+func ErrorFrom(c *fiber.Ctx, err error) error {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return Error(c, fiberErr.Code, "", fiberErr.Message)
+	}
+
+	return Error(c, fiber.StatusInternalServerError, "", http.StatusText(fiber.StatusInternalServerError))
+}
+
+func statusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "error"
+	}
+
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
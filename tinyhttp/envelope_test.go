@@ -0,0 +1,46 @@
+package tinyhttp
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestEnvelopeErrors(t *testing.T) {
+	// given
+	server := NewServer("address", &ServerConfig{EnvelopeErrors: true, DisableRequestID: true})
+	server.OnPanic(func(c *fiber.Ctx, recovered any) {})
+	server.Get("/ok", func(c *fiber.Ctx) error {
+		return OK(c, "payload")
+	})
+	server.Get("/panic", func(c *fiber.Ctx) error {
+		panic("secret")
+	})
+
+	// when
+	fetch := func(path string) (int, string) {
+		req, _ := http.NewRequest("GET", path, nil)
+		response, err := server.App.Test(req, -1)
+		if err != nil {
+			return 0, ""
+		}
+		defer response.Body.Close()
+
+		body, _ := io.ReadAll(response.Body)
+		return response.StatusCode, string(body)
+	}
+
+	okStatus, ok := fetch("/ok")
+	panicStatus, panicBody := fetch("/panic")
+	missingStatus, missing := fetch("/missing")
+
+	// then
+	assert.Equal(t, fiber.StatusOK, okStatus)
+	assert.JSONEq(t, `{"data":"payload"}`, ok)
+	assert.Equal(t, fiber.StatusInternalServerError, panicStatus)
+	assert.JSONEq(t, `{"error":{"code":"internal_server_error","message":"Internal Server Error"}}`, panicBody)
+	assert.Equal(t, fiber.StatusNotFound, missingStatus)
+	assert.JSONEq(t, `{"error":{"code":"not_found","message":"Cannot GET /missing"}}`, missing)
+}
@@ -0,0 +1,190 @@
+package tinyhttp
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"strings"
+)
+
+// hostRouter is a fiber.Router registering handlers that are executed only for requests to matching hosts.
+// For other requests, the handlers pass the control to the next matching route.
+type hostRouter struct {
+	router fiber.Router
+	prefix string
+	match  func(host string) bool
+}
+
+// Host returns a router scoped to the requests with given Host header. The pattern may start with a wildcard
+// ("*.example.com"), matching any subdomain. Routes registered with the router are skipped for requests
+// to other hosts, so the same paths can be registered for multiple hosts. Applications mounted with
+// Mount handle the requests entirely, with the prefix stripped from the path.
+func (s *Server) Host(pattern string) fiber.Router {
+	return &hostRouter{
+		router: s.App,
+		match:  hostMatcher(pattern),
+	}
+}
+
+func (r *hostRouter) Use(args ...any) fiber.Router {
+	for i, arg := range args {
+		if handler, ok := arg.(fiber.Handler); ok {
+			args[i] = r.wrap(handler)
+		}
+	}
+
+	r.router.Use(args...)
+	return r
+}
+
+func (r *hostRouter) Get(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Add(fiber.MethodGet, path, handlers...)
+}
+
+func (r *hostRouter) Head(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Add(fiber.MethodHead, path, handlers...)
+}
+
+func (r *hostRouter) Post(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Add(fiber.MethodPost, path, handlers...)
+}
+
+func (r *hostRouter) Put(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Add(fiber.MethodPut, path, handlers...)
+}
+
+func (r *hostRouter) Delete(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Add(fiber.MethodDelete, path, handlers...)
+}
+
+func (r *hostRouter) Connect(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Add(fiber.MethodConnect, path, handlers...)
+}
+
+func (r *hostRouter) Options(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Add(fiber.MethodOptions, path, handlers...)
+}
+
+func (r *hostRouter) Trace(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Add(fiber.MethodTrace, path, handlers...)
+}
+
+func (r *hostRouter) Patch(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Add(fiber.MethodPatch, path, handlers...)
+}
+
+func (r *hostRouter) Add(method, path string, handlers ...fiber.Handler) fiber.Router {
+	r.router.Add(method, path, r.wrapAll(handlers)...)
+	return r
+}
+
+func (r *hostRouter) Static(prefix, root string, config ...fiber.Static) fiber.Router {
+	var static fiber.Static
+	if config != nil {
+		static = config[0]
+	}
+
+	next := static.Next
+	static.Next = func(c *fiber.Ctx) bool {
+		if !r.match(c.Hostname()) {
+			return true
+		}
+
+		return next != nil && next(c)
+	}
+
+	r.router.Static(prefix, root, static)
+	return r
+}
+
+func (r *hostRouter) All(path string, handlers ...fiber.Handler) fiber.Router {
+	r.router.All(path, r.wrapAll(handlers)...)
+	return r
+}
+
+func (r *hostRouter) Group(prefix string, handlers ...fiber.Handler) fiber.Router {
+	return &hostRouter{
+		router: r.router.Group(prefix, r.wrapAll(handlers)...),
+		prefix: r.prefix + strings.TrimSuffix(prefix, "/"),
+		match:  r.match,
+	}
+}
+
+func (r *hostRouter) Route(prefix string, fn func(router fiber.Router), name ...string) fiber.Router {
+	group := r.Group(prefix)
+	if len(name) > 0 {
+		group.Name(name[0])
+	}
+
+	fn(group)
+	return group
+}
+
+func (r *hostRouter) Mount(prefix string, app *fiber.App) fiber.Router {
+	prefix = strings.TrimSuffix(prefix, "/")
+	fullPrefix := r.prefix + prefix
+	handler := app.Handler()
+
+	r.router.Use(prefix, func(c *fiber.Ctx) error {
+		if !r.match(c.Hostname()) {
+			return c.Next()
+		}
+
+		path := strings.TrimPrefix(c.Path(), fullPrefix)
+		if path == "" {
+			path = "/"
+		}
+
+		c.Request().URI().SetPath(path)
+		handler(c.Context())
+		return nil
+	})
+
+	return r
+}
+
+func (r *hostRouter) Name(name string) fiber.Router {
+	r.router.Name(name)
+	return r
+}
+
+func (r *hostRouter) wrap(handler fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !r.match(c.Hostname()) {
+			return c.Next()
+		}
+
+		return handler(c)
+	}
+}
+
+func (r *hostRouter) wrapAll(handlers []fiber.Handler) []fiber.Handler {
+	wrapped := make([]fiber.Handler, len(handlers))
+	for i, handler := range handlers {
+		wrapped[i] = r.wrap(handler)
+	}
+
+	return wrapped
+}
+
+func hostMatcher(pattern string) func(host string) bool {
+	pattern = strings.ToLower(pattern)
+
+	if strings.HasPrefix(pattern, "*.") {
+		suffix := pattern[1:]
+		return func(host string) bool {
+			host = strings.ToLower(stripPort(host))
+			return strings.HasSuffix(host, suffix) && len(host) > len(suffix)
+		}
+	}
+
+	return func(host string) bool {
+		return strings.ToLower(stripPort(host)) == pattern
+	}
+}
+
+func stripPort(host string) string {
+	if i := strings.LastIndexByte(host, ':'); i != -1 && !strings.HasSuffix(host, "]") {
+		return host[:i]
+	}
+
+	return host
+}
@@ -0,0 +1,44 @@
+package tinyhttp
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestHostRouting(t *testing.T) {
+	// given
+	server := NewServer("address")
+	server.Host("api.example.com").Get("/", func(c *fiber.Ctx) error {
+		return c.SendString("api")
+	})
+	server.Host("*.tenants.example.com").Group("/app").Get("/", func(c *fiber.Ctx) error {
+		return c.SendString("tenant")
+	})
+	server.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString("default")
+	})
+
+	// when
+	fetch := func(host, path string) string {
+		req, _ := http.NewRequest("GET", path, nil)
+		req.Host = host
+		response, err := server.App.Test(req, -1)
+		if err != nil {
+			return ""
+		}
+		defer response.Body.Close()
+
+		body, _ := io.ReadAll(response.Body)
+		return string(body)
+	}
+
+	// then
+	assert.Equal(t, "api", fetch("api.example.com", "/"), "host route should match")
+	assert.Equal(t, "api", fetch("API.example.com:8080", "/"), "host should be matched case-insensitively without port")
+	assert.Equal(t, "tenant", fetch("acme.tenants.example.com", "/app"), "wildcard host should match")
+	assert.Equal(t, "default", fetch("tenants.example.com", "/"), "wildcard should not match the parent domain")
+	assert.Equal(t, "default", fetch("other.example.com", "/"), "other hosts should fall through")
+}
@@ -0,0 +1,57 @@
+package httpauth
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// VerifyCredentialsFunc is a user-provided function that is called in able to validate given username and password.
+type VerifyCredentialsFunc = func(c *fiber.Ctx, username, password string) (*VerificationResult, error)
+
+// NewBasicAuthMiddleware creates new Basic Auth based Middleware.
+// This middleware reads Authorization header and expects it to begin with "Basic" string.
+// Requests without valid credentials are rejected without calling verifyCredentials.
+func NewBasicAuthMiddleware(verifyCredentials VerifyCredentialsFunc, config ...*MiddlewareConfig) *Middleware {
+	c := &MiddlewareConfig{}
+	if config != nil {
+		c = config[0]
+	}
+
+	return newMiddleware(
+		func(c *fiber.Ctx) (*VerificationResult, error) {
+			username, password, ok := extractCredentials(c)
+			if !ok {
+				return &VerificationResult{}, nil
+			}
+
+			return verifyCredentials(c, username, password)
+		},
+		c,
+	)
+}
+
+func extractCredentials(c *fiber.Ctx) (string, string, bool) {
+	authorizationHeader := c.Get("Authorization")
+	if len(authorizationHeader) == 0 {
+		return "", "", false
+	}
+
+	fields := strings.Fields(authorizationHeader)
+	if len(fields) != 2 || !strings.EqualFold(fields[0], "Basic") {
+		return "", "", false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", "", false
+	}
+
+	username, password, found := strings.Cut(string(decoded), ":")
+	if !found || username == "" || password == "" {
+		return "", "", false
+	}
+
+	return username, password, true
+}
@@ -155,3 +155,46 @@ func createBearerTokenMiddleware(correctToken string) *Middleware {
 		}
 	})
 }
+
+func TestValidBasicCredentials(t *testing.T) {
+	// given
+	middleware := NewBasicAuthMiddleware(func(c *fiber.Ctx, username, password string) (*VerificationResult, error) {
+		if username == "user" && password == "secret" {
+			return &VerificationResult{Verified: true, Roles: []string{"ADMIN"}}, nil
+		} else {
+			return &VerificationResult{}, nil
+		}
+	})
+
+	app := tinyhttp.NewServer("address").App
+	app.Get(
+		"/secured",
+		middleware.AnyOfRoles("ADMIN"),
+		func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		},
+	)
+
+	// when
+	validRequest, _ := http.NewRequest("GET", "/secured", nil)
+	validRequest.SetBasicAuth("user", "secret")
+
+	invalidRequest, _ := http.NewRequest("GET", "/secured", nil)
+	invalidRequest.SetBasicAuth("user", "")
+
+	validResponse, err := app.Test(validRequest, -1)
+	if err != nil {
+		assert.Error(t, err)
+		return
+	}
+
+	invalidResponse, err := app.Test(invalidRequest, -1)
+	if err != nil {
+		assert.Error(t, err)
+		return
+	}
+
+	// then
+	assert.Equal(t, fiber.StatusOK, validResponse.StatusCode, "response code should be 200")
+	assert.Equal(t, fiber.StatusUnauthorized, invalidResponse.StatusCode, "response code should be 401")
+}
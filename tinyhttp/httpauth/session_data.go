@@ -4,6 +4,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// sessionDataContextKey is also read by tinyhttp.Context, keep them in sync.
 const sessionDataContextKey = "httpauth/sessionData"
 
 // GetSessionData tries to extract session data set by middleware from the request's context.
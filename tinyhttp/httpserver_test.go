@@ -1,11 +1,16 @@
 package tinyhttp
 
 import (
+	"context"
+	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/mkorman9/tiny"
 	"github.com/stretchr/testify/assert"
 	"io"
+	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -43,3 +48,113 @@ func TestHTTPServer(t *testing.T) {
 	assert.Equal(t, fiber.StatusOK, response.StatusCode, "response code should be 200")
 	assert.Equal(t, []byte(payload), responseBody, "response payload should match")
 }
+
+func TestRequestID(t *testing.T) {
+	// given
+	var requestID string
+
+	app := NewServer("address").App
+	app.Get("/test", func(c *fiber.Ctx) error {
+		requestID = RequestID(c)
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	// when
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "request-1")
+	response, err := app.Test(req, -1)
+	if err != nil {
+		assert.Error(t, err)
+		return
+	}
+
+	// then
+	assert.Equal(t, "request-1", requestID, "request ID should be read from header")
+	assert.Equal(t, "request-1", response.Header.Get(fiber.HeaderXRequestID), "request ID should be returned")
+}
+
+func TestServerPort(t *testing.T) {
+	// given
+	server := NewServer("127.0.0.1:0")
+
+	// when
+	go func() {
+		_ = server.Start()
+	}()
+	defer server.Stop()
+	<-server.Ready()
+
+	// then
+	assert.NotZero(t, server.Port(), "port should be assigned")
+}
+
+func TestServerUnixSocket(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "http.sock")
+	stale, _ := net.Listen("unix", path)
+	if unixListener, ok := stale.(*net.UnixListener); ok {
+		unixListener.SetUnlinkOnClose(false)
+	}
+	_ = stale.Close()
+
+	server := NewServer(path, &ServerConfig{Network: "unix", SocketMode: 0600})
+	server.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	started := make(chan struct{})
+	server.OnStart(func() {
+		close(started)
+	})
+
+	// when
+	go func() {
+		_ = server.Start()
+	}()
+	defer server.Stop()
+	<-started
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", path)
+			},
+		},
+	}
+	response, err := client.Get("http://unix/")
+
+	// then
+	assert.NoError(t, err, "stale socket should be replaced")
+	if err == nil {
+		_ = response.Body.Close()
+		assert.Equal(t, fiber.StatusOK, response.StatusCode)
+	}
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "socket mode should be applied")
+}
+
+func TestServerReusePort(t *testing.T) {
+	// given
+	first := NewServer("127.0.0.1:0", &ServerConfig{ReusePort: true})
+	started := make(chan struct{})
+	first.OnStart(func() {
+		close(started)
+	})
+
+	go func() {
+		_ = first.Start()
+	}()
+	defer first.Stop()
+	<-started
+
+	// when
+	second := NewServer(fmt.Sprintf("127.0.0.1:%d", first.Port()), &ServerConfig{ReusePort: true})
+	listener, err := second.bind()
+
+	// then
+	assert.NoError(t, err, "second server should bind to the same port")
+	if err == nil {
+		_ = listener.Close()
+	}
+}
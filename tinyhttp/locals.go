@@ -0,0 +1,73 @@
+package tinyhttp
+
+import (
+	"context"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+)
+
+// sessionDataLocal is the key under which httpauth middlewares store the session data.
+const sessionDataLocal = "httpauth/sessionData"
+
+type requestContextKey struct{}
+
+type requestContext struct {
+	requestID   string
+	sessionData any
+}
+
+// SetLocal stores a value of type T in the request-scoped locals under given key.
+func SetLocal[T any](c *fiber.Ctx, key string, value T) {
+	c.Locals(key, value)
+}
+
+// GetLocal retrieves a value of type T stored in the request-scoped locals under given key.
+// Returns false if the value is missing or has a different type.
+func GetLocal[T any](c *fiber.Ctx, key string) (T, bool) {
+	value, ok := c.Locals(key).(T)
+	return value, ok
+}
+
+// MustGetLocal retrieves a value of type T stored in the request-scoped locals under given key, or panics.
+func MustGetLocal[T any](c *fiber.Ctx, key string) T {
+	value, ok := GetLocal[T](c, key)
+	if !ok {
+		panic(fmt.Sprintf("MustGetLocal() expected local %q of type %T to be present", key, value))
+	}
+
+	return value
+}
+
+// Context returns a context.Context scoped to the request. It's derived from the user context of the request,
+// so it carries the request-scoped logger, and additionally the request ID and the session data set by
+// httpauth middlewares. It's meant to be passed down to the code that is not aware of fiber.
+func Context(c *fiber.Ctx) context.Context {
+	return context.WithValue(c.UserContext(), requestContextKey{}, &requestContext{
+		requestID:   RequestID(c),
+		sessionData: c.Locals(sessionDataLocal),
+	})
+}
+
+// RequestIDFromContext returns the ID of the request the context has been created for by Context,
+// or empty string if it's not present.
+func RequestIDFromContext(ctx context.Context) string {
+	if rc, ok := ctx.Value(requestContextKey{}).(*requestContext); ok {
+		return rc.requestID
+	}
+
+	return ""
+}
+
+// SessionDataFromContext returns the session data of type T of the request the context has been created
+// for by Context. Returns false if the session data is missing or has a different type.
+func SessionDataFromContext[T any](ctx context.Context) (T, bool) {
+	var empty T
+
+	rc, ok := ctx.Value(requestContextKey{}).(*requestContext)
+	if !ok {
+		return empty, false
+	}
+
+	value, ok := rc.sessionData.(T)
+	return value, ok
+}
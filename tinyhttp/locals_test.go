@@ -0,0 +1,36 @@
+package tinyhttp
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestLocals(t *testing.T) {
+	// given
+	var count int
+	var countFound, wrongTypeFound bool
+	var requestID string
+
+	server := NewServer("address")
+	server.Get("/", func(c *fiber.Ctx) error {
+		SetLocal(c, "count", 42)
+
+		count, countFound = GetLocal[int](c, "count")
+		_, wrongTypeFound = GetLocal[string](c, "count")
+		requestID = RequestIDFromContext(Context(c))
+		return nil
+	})
+
+	// when
+	req, _ := http.NewRequest("GET", "/", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "request-1")
+	_, _ = server.App.Test(req, -1)
+
+	// then
+	assert.True(t, countFound, "local should be found")
+	assert.Equal(t, 42, count, "local should have the stored value")
+	assert.False(t, wrongTypeFound, "local of different type should not be found")
+	assert.Equal(t, "request-1", requestID, "context should carry the request ID")
+}
@@ -0,0 +1,341 @@
+package tinyhttp
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/valyala/fasthttp"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ProxyConfig holds a configuration for Proxy.
+type ProxyConfig struct {
+	// Upstreams is a list of additional upstream addresses. Requests are distributed between the target
+	// and these upstreams in round-robin fashion (default: empty).
+	Upstreams []string
+
+	// MaxRetries is a maximal number of times the request is retried against the next upstream
+	// (default: number of upstreams - 1). Requests with idempotent methods (GET, HEAD, OPTIONS, TRACE, PUT, DELETE)
+	// are retried when the upstream fails or responds with 502, 503 or 504. Other requests are retried only when
+	// the connection to the upstream can't be established, so they're never sent twice.
+	MaxRetries int
+
+	// DisableRetries disables retrying the requests against the next upstream (default: false).
+	DisableRetries bool
+
+	// StripPrefix is a prefix removed from the request path before forwarding it to the upstream (default: "").
+	StripPrefix string
+
+	// PreserveHost defines whether to pass the original Host header to the upstream (default: false).
+	PreserveHost bool
+
+	// SetRequestHeaders is a set of headers added to the requests forwarded to the upstream (default: empty).
+	SetRequestHeaders map[string]string
+
+	// RemoveRequestHeaders is a list of headers removed from the requests forwarded to the upstream
+	// (default: empty).
+	RemoveRequestHeaders []string
+
+	// SetResponseHeaders is a set of headers added to the responses returned from the upstream (default: empty).
+	SetResponseHeaders map[string]string
+
+	// RemoveResponseHeaders is a list of headers removed from the responses returned from the upstream
+	// (default: empty).
+	RemoveResponseHeaders []string
+
+	// Timeout is a maximal time of a single attempt of forwarding the request (default: 30s).
+	Timeout time.Duration
+
+	// TLSConfig is a TLS configuration used when connecting to HTTPS upstreams (default: nil).
+	TLSConfig *tls.Config
+}
+
+var hopByHopHeaders = []string{
+	fiber.HeaderConnection,
+	fiber.HeaderKeepAlive,
+	fiber.HeaderProxyAuthenticate,
+	fiber.HeaderProxyAuthorization,
+	fiber.HeaderTE,
+	fiber.HeaderTrailer,
+	fiber.HeaderTransferEncoding,
+	fiber.HeaderUpgrade,
+}
+
+type proxy struct {
+	config    *ProxyConfig
+	upstreams []*url.URL
+	client    *fasthttp.Client
+	next      uint32
+}
+
+// Proxy creates a handler forwarding requests to the target upstream (for example "http://127.0.0.1:8080")
+// and sending back its responses. WebSocket upgrade requests are passed through to the upstream as well.
+// Invalid upstream addresses cause a panic.
+func Proxy(target string, config ...*ProxyConfig) fiber.Handler {
+	var providedConfig *ProxyConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeProxyConfig(providedConfig)
+
+	p := &proxy{
+		config: c,
+		client: &fasthttp.Client{
+			NoDefaultUserAgentHeader: true,
+			DisablePathNormalizing:   true,
+			TLSConfig:                c.TLSConfig,
+		},
+	}
+
+	for _, address := range append([]string{target}, c.Upstreams...) {
+		upstream, err := url.Parse(strings.TrimSuffix(address, "/"))
+		if err != nil || upstream.Host == "" {
+			panic(fmt.Sprintf("invalid proxy upstream: %s", address))
+		}
+
+		p.upstreams = append(p.upstreams, upstream)
+	}
+
+	if c.DisableRetries {
+		c.MaxRetries = 0
+	} else if providedConfig == nil || providedConfig.MaxRetries <= 0 {
+		c.MaxRetries = len(p.upstreams) - 1
+	}
+
+	return p.handle
+}
+
+func (p *proxy) handle(c *fiber.Ctx) error {
+	start := int(atomic.AddUint32(&p.next, 1))
+
+	if websocket.IsWebSocketUpgrade(c) {
+		return p.forwardWebSocket(c, p.upstreams[start%len(p.upstreams)])
+	}
+
+	var lastErr error
+
+	for attempt := 0; ; attempt++ {
+		upstream := p.upstreams[(start+attempt)%len(p.upstreams)]
+		response := fasthttp.AcquireResponse()
+
+		err := p.forward(c, upstream, response)
+		if err != nil {
+			lastErr = err
+			log.Debug().Err(err).Msgf("Proxy upstream %s failed", upstream.Host)
+		}
+
+		if attempt < p.config.MaxRetries && shouldRetry(c, err, response) {
+			fasthttp.ReleaseResponse(response)
+			continue
+		}
+
+		if err != nil {
+			fasthttp.ReleaseResponse(response)
+
+			log.Error().Err(lastErr).Msgf("Proxy failed to forward request to any upstream")
+			return fiber.ErrBadGateway
+		}
+
+		response.CopyTo(c.Response())
+		fasthttp.ReleaseResponse(response)
+
+		for _, header := range p.config.RemoveResponseHeaders {
+			c.Response().Header.Del(header)
+		}
+		for header, value := range p.config.SetResponseHeaders {
+			c.Set(header, value)
+		}
+
+		return nil
+	}
+}
+
+func (p *proxy) forward(c *fiber.Ctx, upstream *url.URL, response *fasthttp.Response) error {
+	request := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(request)
+
+	c.Request().CopyTo(request)
+	request.SetRequestURI(upstream.String() + p.upstreamPath(c))
+
+	for _, header := range hopByHopHeaders {
+		request.Header.Del(header)
+	}
+	p.rewriteRequestHeaders(c, &request.Header)
+
+	if p.config.PreserveHost {
+		request.UseHostHeader = true
+		request.Header.SetHost(c.Hostname())
+	}
+
+	return p.client.DoTimeout(request, response, p.config.Timeout)
+}
+
+func (p *proxy) forwardWebSocket(c *fiber.Ctx, upstream *url.URL) error {
+	upstreamConn, err := p.dial(upstream)
+	if err != nil {
+		log.Error().Err(err).Msgf("Proxy failed to connect to upstream %s", upstream.Host)
+		return fiber.ErrBadGateway
+	}
+
+	header := &fasthttp.RequestHeader{}
+	c.Request().Header.CopyTo(header)
+	header.SetRequestURI(upstream.Path + p.upstreamPath(c))
+	p.rewriteRequestHeaders(c, header)
+
+	if !p.config.PreserveHost {
+		header.SetHost(upstream.Host)
+	}
+
+	if _, err := upstreamConn.Write(header.Header()); err != nil {
+		_ = upstreamConn.Close()
+		return fiber.ErrBadGateway
+	}
+
+	c.Context().HijackSetNoResponse(true)
+	c.Context().Hijack(func(clientConn net.Conn) {
+		defer upstreamConn.Close()
+
+		done := make(chan struct{}, 2)
+		pipe := func(dst io.Writer, src io.Reader) {
+			_, _ = io.Copy(dst, src)
+			done <- struct{}{}
+		}
+
+		go pipe(upstreamConn, clientConn)
+		go pipe(clientConn, upstreamConn)
+
+		<-done
+	})
+
+	return nil
+}
+
+func (p *proxy) dial(upstream *url.URL) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: p.config.Timeout}
+
+	switch upstream.Scheme {
+	case "https", "wss":
+		return tls.DialWithDialer(dialer, "tcp", hostWithPort(upstream, "443"), p.config.TLSConfig)
+	case "http", "ws", "":
+		return dialer.Dial("tcp", hostWithPort(upstream, "80"))
+	default:
+		return nil, errors.New("unsupported upstream scheme: " + upstream.Scheme)
+	}
+}
+
+func (p *proxy) upstreamPath(c *fiber.Ctx) string {
+	path := c.OriginalURL()
+	if p.config.StripPrefix != "" && strings.HasPrefix(path, p.config.StripPrefix) {
+		path = strings.TrimPrefix(path, p.config.StripPrefix)
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+	}
+
+	return path
+}
+
+func (p *proxy) rewriteRequestHeaders(c *fiber.Ctx, header *fasthttp.RequestHeader) {
+	header.Set(fiber.HeaderXForwardedFor, c.IP())
+	header.Set(fiber.HeaderXForwardedHost, c.Hostname())
+	header.Set(fiber.HeaderXForwardedProto, c.Protocol())
+
+	for _, name := range p.config.RemoveRequestHeaders {
+		header.Del(name)
+	}
+	for name, value := range p.config.SetRequestHeaders {
+		header.Set(name, value)
+	}
+}
+
+// shouldRetry decides whether the request can be safely repeated against the next upstream. Requests with
+// idempotent methods are retried on any failure, the other ones only when they couldn't have reached the upstream.
+func shouldRetry(c *fiber.Ctx, err error, response *fasthttp.Response) bool {
+	if !isIdempotentMethod(c.Method()) {
+		return err != nil && isDialError(err)
+	}
+
+	return err != nil || isRetryableStatus(response.StatusCode())
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions, fiber.MethodTrace, fiber.MethodPut, fiber.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.Is(err, fasthttp.ErrDialTimeout) || (errors.As(err, &opErr) && opErr.Op == "dial")
+}
+
+func isRetryableStatus(status int) bool {
+	return status == fiber.StatusBadGateway ||
+		status == fiber.StatusServiceUnavailable ||
+		status == fiber.StatusGatewayTimeout
+}
+
+func hostWithPort(u *url.URL, defaultPort string) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+
+	return net.JoinHostPort(u.Hostname(), defaultPort)
+}
+
+func mergeProxyConfig(provided *ProxyConfig) *ProxyConfig {
+	config := &ProxyConfig{
+		Timeout: 30 * time.Second,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.Upstreams != nil {
+		config.Upstreams = provided.Upstreams
+	}
+	if provided.MaxRetries > 0 {
+		config.MaxRetries = provided.MaxRetries
+	}
+	if provided.DisableRetries {
+		config.DisableRetries = true
+	}
+	if provided.StripPrefix != "" {
+		config.StripPrefix = strings.TrimSuffix(provided.StripPrefix, "/")
+	}
+	if provided.PreserveHost {
+		config.PreserveHost = true
+	}
+	if provided.SetRequestHeaders != nil {
+		config.SetRequestHeaders = provided.SetRequestHeaders
+	}
+	if provided.RemoveRequestHeaders != nil {
+		config.RemoveRequestHeaders = provided.RemoveRequestHeaders
+	}
+	if provided.SetResponseHeaders != nil {
+		config.SetResponseHeaders = provided.SetResponseHeaders
+	}
+	if provided.RemoveResponseHeaders != nil {
+		config.RemoveResponseHeaders = provided.RemoveResponseHeaders
+	}
+	if provided.Timeout > 0 {
+		config.Timeout = provided.Timeout
+	}
+	if provided.TLSConfig != nil {
+		config.TLSConfig = provided.TLSConfig
+	}
+
+	return config
+}
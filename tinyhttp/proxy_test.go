@@ -0,0 +1,94 @@
+package tinyhttp
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestProxy(t *testing.T) {
+	// given
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Internal", "secret")
+		_, _ = w.Write([]byte(r.URL.RequestURI() + " " + r.Header.Get("X-Gateway")))
+	}))
+	defer upstream.Close()
+
+	server := NewServer("address")
+	server.All("/api/*", Proxy("http://127.0.0.1:1", &ProxyConfig{
+		Upstreams:             []string{upstream.URL},
+		StripPrefix:           "/api",
+		SetRequestHeaders:     map[string]string{"X-Gateway": "tiny"},
+		RemoveResponseHeaders: []string{"X-Internal"},
+	}))
+
+	// when
+	var responses []*http.Response
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("GET", "/api/users?page=2", nil)
+		response, err := server.App.Test(req, -1)
+		assert.NoError(t, err)
+		responses = append(responses, response)
+	}
+
+	// then
+	for _, response := range responses {
+		body, _ := io.ReadAll(response.Body)
+		_ = response.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, response.StatusCode, "request should be retried against the live upstream")
+		assert.Equal(t, "/users?page=2 tiny", string(body), "path should be stripped and headers rewritten")
+		assert.Empty(t, response.Header.Get("X-Internal"), "response header should be removed")
+	}
+}
+
+func TestProxyRetries(t *testing.T) {
+	// given
+	var hits atomic.Int32
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer live.Close()
+
+	server := NewServer("address")
+	server.All("/unavailable", Proxy(unavailable.URL, &ProxyConfig{Upstreams: []string{unavailable.URL}}))
+	server.All("/unreachable", Proxy("http://127.0.0.1:1", &ProxyConfig{Upstreams: []string{live.URL}}))
+	server.All("/disabled", Proxy("http://127.0.0.1:1", &ProxyConfig{Upstreams: []string{live.URL}, DisableRetries: true}))
+
+	send := func(method, path string) int {
+		req, _ := http.NewRequest(method, path, nil)
+		response, err := server.App.Test(req, -1)
+		if err != nil {
+			return 0
+		}
+		_ = response.Body.Close()
+		return response.StatusCode
+	}
+
+	// when
+	postStatus := send("POST", "/unavailable")
+	postHits := hits.Load()
+	getStatus := send("GET", "/unavailable")
+	getHits := hits.Load() - postHits
+
+	unreachableStatuses := []int{send("POST", "/unreachable"), send("POST", "/unreachable")}
+	disabledStatuses := []int{send("GET", "/disabled"), send("GET", "/disabled")}
+
+	// then
+	assert.Equal(t, fiber.StatusServiceUnavailable, postStatus, "upstream response should be returned")
+	assert.Equal(t, int32(1), postHits, "POST should not be retried after reaching the upstream")
+	assert.Equal(t, fiber.StatusServiceUnavailable, getStatus, "last upstream response should be returned")
+	assert.Equal(t, int32(2), getHits, "GET should be retried against the next upstream")
+	assert.Equal(t, []int{fiber.StatusOK, fiber.StatusOK}, unreachableStatuses, "POST should be retried when the upstream is unreachable")
+	assert.ElementsMatch(t, []int{fiber.StatusOK, fiber.StatusBadGateway}, disabledStatuses, "requests should not be retried when disabled")
+}
@@ -0,0 +1,174 @@
+package tinyhttp
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RateLimitConfig holds a configuration for the rate limiting middleware.
+type RateLimitConfig struct {
+	// Requests is a number of requests each client is allowed to make in Window (default: 60).
+	Requests int
+
+	// Window is a time window the Requests are counted in (default: 1m).
+	Window time.Duration
+
+	// Burst is a maximal number of requests a client can make at once, after being idle (default: Requests).
+	Burst int
+
+	// KeyHeader is an optional name of the header identifying clients, for example "X-API-Key".
+	// Requests without the header are identified by client IP.
+	KeyHeader string
+
+	// KeyFunc is an optional function identifying clients. It takes precedence over KeyHeader (default: client IP).
+	KeyFunc func(c *fiber.Ctx) string
+
+	// OnLimitReached is an optional handler called when the client exceeds its limit.
+	// Retry-After header is already set when it's called (default: respond with 429 Too Many Requests).
+	OnLimitReached fiber.Handler
+}
+
+type tokenBucket struct {
+	tokens     float64
+	lastUpdate time.Time
+}
+
+type rateLimiter struct {
+	config       *RateLimitConfig
+	rate         float64
+	buckets      map[string]*tokenBucket
+	bucketsLock  sync.Mutex
+	lastCleanup  time.Time
+	cleanupEvery time.Duration
+}
+
+// NewRateLimitMiddleware creates a middleware limiting the rate of requests made by each client,
+// using the token bucket algorithm. It can be enabled for the whole server with ServerConfig.RateLimit,
+// or for a group of routes with Use.
+func NewRateLimitMiddleware(config ...*RateLimitConfig) fiber.Handler {
+	var providedConfig *RateLimitConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeRateLimitConfig(providedConfig)
+
+	limiter := &rateLimiter{
+		config:       c,
+		rate:         float64(c.Requests) / c.Window.Seconds(),
+		buckets:      map[string]*tokenBucket{},
+		lastCleanup:  time.Now(),
+		cleanupEvery: c.Window,
+	}
+
+	return limiter.handle
+}
+
+func (l *rateLimiter) handle(c *fiber.Ctx) error {
+	allowed, retryAfter := l.take(l.key(c), time.Now())
+	if allowed {
+		return c.Next()
+	}
+
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+
+	if l.config.OnLimitReached != nil {
+		return l.config.OnLimitReached(c)
+	}
+
+	return c.SendStatus(fiber.StatusTooManyRequests)
+}
+
+func (l *rateLimiter) key(c *fiber.Ctx) string {
+	if l.config.KeyFunc != nil {
+		return l.config.KeyFunc(c)
+	}
+
+	if l.config.KeyHeader != "" {
+		if key := c.Get(l.config.KeyHeader); key != "" {
+			return "key:" + key
+		}
+	}
+
+	return "ip:" + c.IP()
+}
+
+// take consumes a token from the bucket of given client. If there are no tokens left, it returns the time
+// after which the next token will be available.
+func (l *rateLimiter) take(key string, now time.Time) (bool, time.Duration) {
+	l.bucketsLock.Lock()
+	defer l.bucketsLock.Unlock()
+
+	l.cleanup(now)
+
+	burst := float64(l.config.Burst)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: burst, lastUpdate: now}
+		l.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(burst, bucket.tokens+now.Sub(bucket.lastUpdate).Seconds()*l.rate)
+	bucket.lastUpdate = now
+
+	if bucket.tokens < 1 {
+		return false, time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+	}
+
+	bucket.tokens--
+	return true, 0
+}
+
+// cleanup removes buckets that would have been refilled completely, as they're equivalent to missing ones.
+func (l *rateLimiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < l.cleanupEvery {
+		return
+	}
+
+	burst := float64(l.config.Burst)
+	for key, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.lastUpdate).Seconds()*l.rate >= burst {
+			delete(l.buckets, key)
+		}
+	}
+
+	l.lastCleanup = now
+}
+
+func mergeRateLimitConfig(provided *RateLimitConfig) *RateLimitConfig {
+	config := &RateLimitConfig{
+		Requests: 60,
+		Window:   time.Minute,
+	}
+
+	if provided != nil {
+		if provided.Requests > 0 {
+			config.Requests = provided.Requests
+		}
+		if provided.Window > 0 {
+			config.Window = provided.Window
+		}
+		if provided.Burst > 0 {
+			config.Burst = provided.Burst
+		}
+		if provided.KeyHeader != "" {
+			config.KeyHeader = provided.KeyHeader
+		}
+		if provided.KeyFunc != nil {
+			config.KeyFunc = provided.KeyFunc
+		}
+		if provided.OnLimitReached != nil {
+			config.OnLimitReached = provided.OnLimitReached
+		}
+	}
+
+	if config.Burst == 0 {
+		config.Burst = config.Requests
+	}
+
+	return config
+}
@@ -0,0 +1,38 @@
+package tinyhttp
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	// given
+	app := NewServer("address", &ServerConfig{
+		RateLimit: &RateLimitConfig{Requests: 2, Window: time.Hour},
+	}).App
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	// when
+	var statuses []int
+	var retryAfter string
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest("GET", "/test", nil)
+		response, err := app.Test(req, -1)
+		if err != nil {
+			assert.Error(t, err)
+			return
+		}
+
+		statuses = append(statuses, response.StatusCode)
+		retryAfter = response.Header.Get(fiber.HeaderRetryAfter)
+	}
+
+	// then
+	assert.Equal(t, []int{200, 200, 429}, statuses, "third request should be rejected")
+	assert.Equal(t, "1800", retryAfter, "Retry-After should be set")
+}
@@ -0,0 +1,69 @@
+package tinyhttp
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	requestIDLocal     = "tinyhttp.requestId"
+	requestLoggerLocal = "tinyhttp.logger"
+	maxRequestIDLength = 128
+)
+
+// NewRequestIDMiddleware creates a middleware assigning an ID to each request. The ID is read from X-Request-ID
+// header, or generated as UUID if the header is missing. It's added to the response headers and can be retrieved
+// with RequestID. The request-scoped logger returned by Logger includes the ID in each entry.
+// It's enabled by default for the whole server (see ServerConfig.DisableRequestID).
+func NewRequestIDMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(fiber.HeaderXRequestID)
+		if !isValidRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		logger := log.With().Str("requestId", id).Logger()
+
+		c.Locals(requestIDLocal, id)
+		c.Locals(requestLoggerLocal, &logger)
+		c.SetUserContext(logger.WithContext(c.UserContext()))
+		c.Set(fiber.HeaderXRequestID, id)
+
+		return c.Next()
+	}
+}
+
+// RequestID returns the ID of the request assigned by the request ID middleware, or empty string if it's disabled.
+func RequestID(c *fiber.Ctx) string {
+	if id, ok := c.Locals(requestIDLocal).(string); ok {
+		return id
+	}
+
+	return ""
+}
+
+// Logger returns a request-scoped logger, including the ID of the request in each entry.
+// If the request ID middleware is disabled, the global logger is returned.
+func Logger(c *fiber.Ctx) *zerolog.Logger {
+	if logger, ok := c.Locals(requestLoggerLocal).(*zerolog.Logger); ok {
+		return logger
+	}
+
+	return &log.Logger
+}
+
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
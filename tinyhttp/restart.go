@@ -0,0 +1,178 @@
+package tinyhttp
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+const (
+	inheritedListenersEnv = "TINYHTTP_INHERITED_LISTENERS"
+	parentPIDEnv          = "TINYHTTP_PARENT_PID"
+)
+
+var (
+	inheritedFiles     map[string]*os.File
+	inheritedFilesOnce sync.Once
+	inheritedFilesLock sync.Mutex
+	parentPID          int
+	parentNotifyOnce   sync.Once
+)
+
+type filer interface {
+	File() (*os.File, error)
+}
+
+// Restart starts a new instance of the current executable, with the same arguments and environment, passing it
+// the listeners of given servers. Servers of the new process with matching addresses take over the listeners,
+// instead of binding to the addresses, so no connection is refused during the restart.
+// Once all the listeners are served by the new process, it sends SIGTERM to this process, to let it drain
+// the requests in progress within ShutdownTimeout and exit gracefully (see tiny.Run).
+// A typical setup triggers the restart with a signal:
+//
+//	tiny.NewSignalsListener(func(os.Signal) { tinyhttp.Restart(server) }, syscall.SIGUSR2)
+func Restart(servers ...*Server) (*os.Process, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	files := []*os.File{os.Stdin, os.Stdout, os.Stderr}
+	var entries []string
+
+	defer func() {
+		for _, file := range files[3:] {
+			_ = file.Close()
+		}
+	}()
+
+	for _, server := range servers {
+		file, err := server.listenerFile()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", server.address, err)
+		}
+
+		entries = append(entries, fmt.Sprintf("%s=%d", server.address, len(files)))
+		files = append(files, file)
+	}
+
+	var env []string
+	for _, variable := range os.Environ() {
+		if !strings.HasPrefix(variable, inheritedListenersEnv+"=") && !strings.HasPrefix(variable, parentPIDEnv+"=") {
+			env = append(env, variable)
+		}
+	}
+	env = append(
+		env,
+		inheritedListenersEnv+"="+strings.Join(entries, ";"),
+		parentPIDEnv+"="+strconv.Itoa(os.Getpid()),
+	)
+
+	process, err := os.StartProcess(executable, os.Args, &os.ProcAttr{
+		Env:   env,
+		Files: files,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Msgf("Started new process (PID %d), handing over %d listener(s)", process.Pid, len(entries))
+	return process, nil
+}
+
+func (s *Server) listenerFile() (*os.File, error) {
+	s.listenerLock.RLock()
+	defer s.listenerLock.RUnlock()
+
+	if s.bound == nil {
+		return nil, errors.New("server is not started")
+	}
+
+	if unixListener, ok := s.bound.(*net.UnixListener); ok {
+		// the socket file is going to be used by the new process
+		unixListener.SetUnlinkOnClose(false)
+	}
+
+	f, ok := s.bound.(filer)
+	if !ok {
+		return nil, errors.New("listener cannot be passed to another process")
+	}
+
+	return f.File()
+}
+
+// inheritedListener returns a listener passed by the parent process with Restart for given address, if any.
+func inheritedListener(address string) (net.Listener, bool, error) {
+	inheritedFilesOnce.Do(loadInheritedFiles)
+
+	inheritedFilesLock.Lock()
+	file, ok := inheritedFiles[address]
+	delete(inheritedFiles, address)
+	inheritedFilesLock.Unlock()
+
+	if !ok {
+		return nil, false, nil
+	}
+
+	listener, err := net.FileListener(file)
+	_ = file.Close()
+
+	return listener, true, err
+}
+
+// notifyParent sends SIGTERM to the parent process once all the inherited listeners are taken over.
+func notifyParent() {
+	inheritedFilesLock.Lock()
+	remaining := len(inheritedFiles)
+	inheritedFilesLock.Unlock()
+
+	if remaining > 0 || parentPID <= 0 {
+		return
+	}
+
+	parentNotifyOnce.Do(func() {
+		parent, err := os.FindProcess(parentPID)
+		if err == nil {
+			err = parent.Signal(syscall.SIGTERM)
+		}
+
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to notify parent process (PID %d)", parentPID)
+		} else {
+			log.Info().Msgf("Listeners taken over, parent process (PID %d) notified to shut down", parentPID)
+		}
+	})
+}
+
+func loadInheritedFiles() {
+	inheritedFiles = map[string]*os.File{}
+
+	entries := os.Getenv(inheritedListenersEnv)
+	if entries == "" {
+		return
+	}
+
+	parentPID, _ = strconv.Atoi(os.Getenv(parentPIDEnv))
+	_ = os.Unsetenv(inheritedListenersEnv)
+	_ = os.Unsetenv(parentPIDEnv)
+
+	for _, entry := range strings.Split(entries, ";") {
+		i := strings.LastIndexByte(entry, '=')
+		if i == -1 {
+			continue
+		}
+
+		fd, err := strconv.Atoi(entry[i+1:])
+		if err != nil {
+			continue
+		}
+
+		inheritedFiles[entry[:i]] = os.NewFile(uintptr(fd), "inherited-listener")
+	}
+}
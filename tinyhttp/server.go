@@ -1,17 +1,22 @@
 package tinyhttp
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
 	"github.com/goccy/go-json"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/mkorman9/tiny"
 	"github.com/rs/zerolog/log"
 	"net"
+	"os"
+	"sync"
 )
 
 // Server is an object representing fiber.App and implementing the tiny.Service interface.
+// It implements tiny.ReadinessNotifier - it becomes ready when it's bound to the address.
 type Server struct {
 	*fiber.App
 
@@ -19,6 +24,16 @@ type Server struct {
 	address      string
 	errorHandler func(c *fiber.Ctx, err error) error
 	panicHandler func(c *fiber.Ctx, recovered any)
+	onStart      func()
+	onStop       func()
+	acme         *acmeServer
+	certificates *certificateStore
+	listener     net.Listener
+	bound        net.Listener
+	inherited    bool
+	listenerLock sync.RWMutex
+	readyChannel chan struct{}
+	readyOnce    sync.Once
 }
 
 // NewServer creates new Server instance.
@@ -30,8 +45,9 @@ func NewServer(address string, config ...*ServerConfig) *Server {
 	c := mergeServerConfig(providedConfig)
 
 	server := &Server{
-		config:  c,
-		address: address,
+		config:       c,
+		address:      address,
+		readyChannel: make(chan struct{}),
 	}
 	server.App = server.createApp()
 
@@ -42,32 +58,34 @@ func NewServer(address string, config ...*ServerConfig) *Server {
 func (s *Server) Start() error {
 	log.Info().Msgf("HTTP server started (%s)", s.address)
 
-	var listener net.Listener
+	listener, err := s.listen()
+	if err != nil {
+		return err
+	}
 
-	if s.config.TLSCert != "" && s.config.TLSKey != "" {
-		cert, err := tls.LoadX509KeyPair(s.config.TLSCert, s.config.TLSKey)
-		if err != nil {
-			return err
-		}
+	return s.Serve(listener)
+}
 
-		tlsHandler := &fiber.TLSHandler{}
-		s.config.TLSConfig.Certificates = []tls.Certificate{cert}
-		s.config.TLSConfig.GetCertificate = tlsHandler.GetClientInfo
-		s.SetTLSHandler(tlsHandler)
+// Serve starts the server on a pre-bound listener, instead of binding to the address. Like Start, it blocks until
+// the server is stopped. TLS options and MaxConnsPerIP from ServerConfig are not applied to the listener.
+func (s *Server) Serve(listener net.Listener) error {
+	s.listenerLock.Lock()
+	s.listener = listener
+	if s.bound == nil {
+		s.bound = listener
+	}
+	s.listenerLock.Unlock()
 
-		socket, err := tls.Listen(s.config.Network, s.address, s.config.TLSConfig)
-		if err != nil {
-			return err
-		}
+	s.readyOnce.Do(func() {
+		close(s.readyChannel)
+	})
 
-		listener = socket
-	} else {
-		socket, err := net.Listen(s.config.Network, s.address)
-		if err != nil {
-			return err
-		}
+	if s.inherited {
+		notifyParent()
+	}
 
-		listener = socket
+	if s.onStart != nil {
+		s.onStart()
 	}
 
 	return s.Listener(listener)
@@ -75,11 +93,80 @@ func (s *Server) Start() error {
 
 // Stop implements the interface of tiny.Service.
 func (s *Server) Stop() {
+	if s.acme != nil {
+		s.acme.stop(s.config.ShutdownTimeout)
+	}
+	if s.certificates != nil {
+		s.certificates.stop()
+	}
+
+	closeWebSockets(s.App)
+
 	if err := s.ShutdownWithTimeout(s.config.ShutdownTimeout); err != nil {
 		log.Error().Err(err).Msgf("Error shutting down HTTP server (%s)", s.address)
 	} else {
 		log.Info().Msgf("HTTP server stopped (%s)", s.address)
 	}
+
+	if s.onStop != nil {
+		s.onStop()
+	}
+}
+
+// Drain implements the interface of tiny.Drainable.
+// The server stops accepting new connections, while the requests in progress are allowed to finish.
+func (s *Server) Drain() {
+	go func() {
+		if err := s.ShutdownWithTimeout(s.config.ShutdownTimeout); err != nil {
+			log.Error().Err(err).Msgf("Error draining HTTP server (%s)", s.address)
+		}
+	}()
+}
+
+// Ready implements the interface of tiny.ReadinessNotifier.
+func (s *Server) Ready() <-chan struct{} {
+	return s.readyChannel
+}
+
+// Address returns the address the server has been created with.
+func (s *Server) Address() string {
+	return s.address
+}
+
+// ReloadCertificates re-reads the TLS certificate files. The new certificates are served to new connections.
+func (s *Server) ReloadCertificates() error {
+	if s.certificates == nil {
+		return errors.New("TLS is not enabled")
+	}
+
+	return s.certificates.reload()
+}
+
+// Port returns the port the server is bound to, or 0 if it's not started.
+// It's useful when the server is created with port 0, to obtain the port chosen by the system.
+func (s *Server) Port() int {
+	s.listenerLock.RLock()
+	defer s.listenerLock.RUnlock()
+
+	if s.listener == nil {
+		return 0
+	}
+
+	if addr, ok := s.listener.Addr().(*net.TCPAddr); ok {
+		return addr.Port
+	}
+
+	return 0
+}
+
+// OnStart sets a function called after the server is bound to the port, but before it starts accepting connections.
+func (s *Server) OnStart(handler func()) {
+	s.onStart = handler
+}
+
+// OnStop sets a function called after the server is shut down.
+func (s *Server) OnStop(handler func()) {
+	s.onStop = handler
 }
 
 // OnPanic sets a handler for requests that resulted in panic.
@@ -92,6 +179,107 @@ func (s *Server) OnError(handler func(c *fiber.Ctx, err error) error) {
 	s.errorHandler = handler
 }
 
+func (s *Server) listen() (net.Listener, error) {
+	switch {
+	case s.config.ACME != nil:
+		tlsHandler := &fiber.TLSHandler{}
+		s.acme = newACMEServer(s.config.ACME)
+		s.acme.configureTLS(s.config.TLSConfig, tlsHandler)
+		s.SetTLSHandler(tlsHandler)
+
+		listener, err := s.bind()
+		if err != nil {
+			return nil, err
+		}
+
+		s.acme.start()
+		return tls.NewListener(listener, s.config.TLSConfig), nil
+	case (s.config.TLSCert != "" && s.config.TLSKey != "") || len(s.config.TLSCertificates) > 0:
+		var defaultCert *TLSCertificate
+		if s.config.TLSCert != "" && s.config.TLSKey != "" {
+			defaultCert = &TLSCertificate{Cert: s.config.TLSCert, Key: s.config.TLSKey}
+		}
+
+		certificates, err := newCertificateStore(defaultCert, s.config.TLSCertificates)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsHandler := &fiber.TLSHandler{}
+		s.config.TLSConfig.GetCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			_, _ = tlsHandler.GetClientInfo(hello)
+			return certificates.getCertificate(hello)
+		}
+		s.SetTLSHandler(tlsHandler)
+
+		listener, err := s.bind()
+		if err != nil {
+			return nil, err
+		}
+
+		s.certificates = certificates
+		if s.config.TLSReloadInterval > 0 {
+			go certificates.watch(s.config.TLSReloadInterval)
+		}
+
+		return tls.NewListener(listener, s.config.TLSConfig), nil
+	default:
+		return s.bind()
+	}
+}
+
+func (s *Server) bind() (net.Listener, error) {
+	listener, inherited, err := inheritedListener(s.address)
+	if !inherited {
+		switch {
+		case s.config.SocketActivation:
+			listener, err = tiny.SystemdListener(s.config.SocketName)
+		case s.config.Network == "unix":
+			listener, err = listenUnix(s.address, s.config.SocketMode)
+		case s.config.ReusePort:
+			listenConfig := &net.ListenConfig{Control: reusePortControl}
+			listener, err = listenConfig.Listen(context.Background(), s.config.Network, s.address)
+		default:
+			listener, err = net.Listen(s.config.Network, s.address)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.listenerLock.Lock()
+	s.bound = listener
+	s.inherited = inherited
+	s.listenerLock.Unlock()
+
+	// the limit wraps the raw listener, so that TLS connections are still seen as *tls.Conn by the server
+	if s.config.MaxConnsPerIP > 0 {
+		return newConnLimitListener(listener, s.config.MaxConnsPerIP), nil
+	}
+
+	return listener, nil
+}
+
+func listenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(path, mode); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
+
 func (s *Server) createApp() *fiber.App {
 	appConfig := fiber.Config{
 		ErrorHandler:          s.errorFunction,
@@ -124,15 +312,40 @@ func (s *Server) createApp() *fiber.App {
 	}
 
 	app := fiber.New(appConfig)
+	app.Server().MaxRequestsPerConn = s.config.MaxRequestsPerConn
+
+	if s.config.ProblemDetails {
+		app.Use(func(c *fiber.Ctx) error {
+			SetLocal(c, problemDetailsLocal, true)
+			return c.Next()
+		})
+	}
+
+	// access log is registered before recover, so the requests ending with a panic are logged too
+	if s.config.AccessLog != nil {
+		app.Use(NewAccessLogMiddleware(s.config.AccessLog))
+	}
 
 	app.Use(recover.New(recover.Config{
 		StackTraceHandler: s.recoveryFunction,
 	}))
 
+	if !s.config.DisableRequestID {
+		app.Use(NewRequestIDMiddleware())
+	}
+
+	if s.config.Compression != nil {
+		app.Use(NewCompressionMiddleware(s.config.Compression))
+	}
+
 	if s.config.SecurityHeaders {
 		app.Use(s.securityHeadersFunction)
 	}
 
+	if s.config.RateLimit != nil {
+		app.Use(NewRateLimitMiddleware(s.config.RateLimit))
+	}
+
 	return app
 }
 
@@ -141,6 +354,10 @@ func (s *Server) errorFunction(c *fiber.Ctx, err error) error {
 		return s.errorHandler(c, err)
 	}
 
+	if s.config.EnvelopeErrors {
+		return ErrorFrom(c, err)
+	}
+
 	code := fiber.StatusInternalServerError
 
 	var fiberErr *fiber.Error
@@ -0,0 +1,114 @@
+package tinyhttp
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// StaticConfig holds a configuration for Server.ServeStatic.
+type StaticConfig struct {
+	// MaxAge is a duration the files can be cached by clients, sent in Cache-Control header (default: 0).
+	MaxAge time.Duration
+
+	// Index is a name of the file served for directories (default: "index.html").
+	Index string
+
+	// SPA enables single-page application mode - the index file is served for unknown paths without
+	// an extension, so the routing can be handled by the frontend (default: false).
+	SPA bool
+
+	// Compress enables serving compressed versions of the files, cached on disk (default: false).
+	Compress bool
+
+	// Browse enables directory listing (default: false).
+	Browse bool
+}
+
+// ServeStatic serves files from root directory under given prefix. Byte range requests are supported,
+// and weak ETags are generated from the size and modification time of the files.
+func (s *Server) ServeStatic(prefix, root string, config ...*StaticConfig) {
+	var providedConfig *StaticConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeStaticConfig(providedConfig)
+
+	s.App.Static(prefix, root, fiber.Static{
+		Compress:       c.Compress,
+		ByteRange:      true,
+		Browse:         c.Browse,
+		Index:          c.Index,
+		MaxAge:         int(c.MaxAge.Seconds()),
+		ModifyResponse: setStaticETag,
+	})
+
+	if c.SPA {
+		indexPath := filepath.Join(root, c.Index)
+
+		s.App.Get(strings.TrimSuffix(prefix, "/")+"/*", func(ctx *fiber.Ctx) error {
+			if path.Ext(ctx.Path()) != "" {
+				return ctx.Next()
+			}
+
+			ctx.Set(fiber.HeaderCacheControl, "no-cache")
+			return ctx.SendFile(indexPath)
+		})
+	}
+}
+
+// setStaticETag sets weak ETag based on the size and modification time of the served file
+// and responds with 304 Not Modified if it matches If-None-Match header.
+func setStaticETag(c *fiber.Ctx) error {
+	response := c.Response()
+	if response.StatusCode() != fiber.StatusOK {
+		return nil
+	}
+
+	lastModified, err := time.Parse(time.RFC1123, string(response.Header.Peek(fiber.HeaderLastModified)))
+	if err != nil {
+		return nil
+	}
+
+	etag := fmt.Sprintf(`W/"%x-%x"`, response.Header.ContentLength(), lastModified.Unix())
+	c.Set(fiber.HeaderETag, etag)
+
+	if c.Get(fiber.HeaderIfNoneMatch) == etag {
+		response.ResetBody()
+		response.SetStatusCode(fiber.StatusNotModified)
+	}
+
+	return nil
+}
+
+func mergeStaticConfig(provided *StaticConfig) *StaticConfig {
+	config := &StaticConfig{
+		Index: "index.html",
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.MaxAge > 0 {
+		config.MaxAge = provided.MaxAge
+	}
+	if provided.Index != "" {
+		config.Index = provided.Index
+	}
+	if provided.SPA {
+		config.SPA = true
+	}
+	if provided.Compress {
+		config.Compress = true
+	}
+	if provided.Browse {
+		config.Browse = true
+	}
+
+	return config
+}
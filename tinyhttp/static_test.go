@@ -0,0 +1,56 @@
+package tinyhttp
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStaticSPA(t *testing.T) {
+	// given
+	root := t.TempDir()
+	_ = os.WriteFile(filepath.Join(root, "index.html"), []byte("index"), 0644)
+	_ = os.WriteFile(filepath.Join(root, "app.js"), []byte("script"), 0644)
+
+	server := NewServer("address")
+	server.ServeStatic("/", root, &StaticConfig{SPA: true})
+
+	// when
+	fetch := func(path string) (int, string) {
+		req, _ := http.NewRequest("GET", path, nil)
+		response, err := server.App.Test(req, -1)
+		if err != nil {
+			return 0, ""
+		}
+		defer response.Body.Close()
+
+		body, _ := io.ReadAll(response.Body)
+		return response.StatusCode, string(body)
+	}
+
+	scriptStatus, script := fetch("/app.js")
+	routeStatus, route := fetch("/users/1")
+	missingStatus, _ := fetch("/missing.css")
+
+	// then
+	assert.Equal(t, fiber.StatusOK, scriptStatus, "existing file should be served")
+	assert.Equal(t, "script", script, "file content should match")
+	assert.Equal(t, fiber.StatusOK, routeStatus, "unknown route should be served")
+	assert.Equal(t, "index", route, "index should be served for unknown route")
+	assert.Equal(t, fiber.StatusNotFound, missingStatus, "missing file should not fall back to index")
+}
+
+func TestServerIsRouter(t *testing.T) {
+	// given
+	var router fiber.Router = NewServer("address")
+
+	// when
+	result := router.Static("/files", t.TempDir(), fiber.Static{Browse: true})
+
+	// then
+	assert.NotNil(t, result, "fiber's Static should be available on the server")
+}
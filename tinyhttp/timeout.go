@@ -0,0 +1,33 @@
+package tinyhttp
+
+import (
+	"context"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"time"
+)
+
+// Timeout creates a middleware enforcing a deadline on the execution of the following handlers.
+// The deadline is propagated through the user context of the request (see fiber.Ctx.UserContext and Context),
+// so it bounds the downstream calls accepting context.Context. Handlers are not interrupted forcibly,
+// but when the deadline expires, the request is finished with 504 Gateway Timeout regardless of the response
+// prepared by the handler.
+func Timeout(d time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.UserContext(), d)
+		defer cancel()
+
+		parent := c.UserContext()
+		c.SetUserContext(ctx)
+		defer c.SetUserContext(parent)
+
+		err := c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) || errors.Is(err, context.DeadlineExceeded) {
+			c.Response().ResetBody()
+			return fiber.ErrGatewayTimeout
+		}
+
+		return err
+	}
+}
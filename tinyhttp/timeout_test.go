@@ -0,0 +1,33 @@
+package tinyhttp
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTimeout(t *testing.T) {
+	// given
+	server := NewServer("address")
+	server.Get("/slow", Timeout(10*time.Millisecond), func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return c.UserContext().Err()
+	})
+	server.Get("/fast", Timeout(time.Second), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	// when
+	slowReq, _ := http.NewRequest("GET", "/slow", nil)
+	slowResponse, slowErr := server.App.Test(slowReq, -1)
+	fastReq, _ := http.NewRequest("GET", "/fast", nil)
+	fastResponse, fastErr := server.App.Test(fastReq, -1)
+
+	// then
+	assert.NoError(t, slowErr)
+	assert.NoError(t, fastErr)
+	assert.Equal(t, fiber.StatusGatewayTimeout, slowResponse.StatusCode, "slow request should time out")
+	assert.Equal(t, fiber.StatusOK, fastResponse.StatusCode, "fast request should succeed")
+}
@@ -0,0 +1,267 @@
+package tinyhttp
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"math"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FileConfig holds constraints of the uploaded files, checked by BindFile.
+type FileConfig struct {
+	// Required defines whether at least one file must be uploaded (default: false).
+	Required bool
+
+	// MaxCount is a maximal number of files uploaded under the field (default: 1).
+	MaxCount int
+
+	// MaxSize is a maximal size of a single file, in bytes (default: 0, limited only by ServerConfig.BodyLimit).
+	MaxSize int64
+
+	// AllowedTypes is a list of allowed MIME types, such as "image/png" or "image/*". The type is detected from
+	// the content of the file, the type declared by the client is ignored (default: empty, all types allowed).
+	AllowedTypes []string
+}
+
+var fileHeaderType = reflect.TypeOf(&multipart.FileHeader{})
+
+// BindFile extracts files uploaded under given field of multipart form and checks them against the constraints.
+// Violated constraints are returned as ValidationError with tags "required", "maxCount", "maxSize" or "type".
+func BindFile(c *fiber.Ctx, field string, config ...*FileConfig) ([]*multipart.FileHeader, []ValidationError) {
+	var providedConfig *FileConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	fc := mergeFileConfig(providedConfig)
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, []ValidationError{
+			{Field: "body", Tag: "format"},
+		}
+	}
+
+	files := form.File[field]
+
+	if errors := checkFiles(field, files, fc); errors != nil {
+		return nil, errors
+	}
+
+	return files, nil
+}
+
+// BindMultipart tries to parse provided multipart form into the object and validate it using the DefaultValidator.
+// Fields of type *multipart.FileHeader or []*multipart.FileHeader are bound to the uploaded files, according to
+// the `file` tag, which specifies the name of the form field followed by optional constraints (the number of files
+// is not limited for slices, unless maxCount is specified), for example:
+//
+//	Avatar *multipart.FileHeader `file:"avatar,required,maxSize=1048576,types=image/png|image/jpeg"`
+//	Photos []*multipart.FileHeader `file:"photos,maxCount=10,types=image/*"`
+func BindMultipart(c *fiber.Ctx, out any) []ValidationError {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return []ValidationError{
+			{Field: "body", Tag: "format"},
+		}
+	}
+
+	if err := c.BodyParser(out); err != nil {
+		return []ValidationError{
+			{Field: "body", Tag: "format"},
+		}
+	}
+
+	value := reflect.ValueOf(out)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return []ValidationError{
+			{Field: "body", Tag: "format"},
+		}
+	}
+
+	var result []ValidationError
+
+	value = value.Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+
+		tag := field.Tag.Get("file")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		name, provided, err := parseFileTag(tag)
+		if err != nil {
+			panic(fmt.Sprintf("invalid file tag of field %s: %v", field.Name, err))
+		}
+
+		switch field.Type {
+		case fileHeaderType:
+			provided.MaxCount = 1
+		case reflect.SliceOf(fileHeaderType):
+			if provided.MaxCount == 0 {
+				provided.MaxCount = math.MaxInt
+			}
+		default:
+			panic(fmt.Sprintf("file tag on field %s of unsupported type %s", field.Name, field.Type))
+		}
+
+		files := form.File[name]
+
+		if errors := checkFiles(name, files, mergeFileConfig(provided)); errors != nil {
+			result = append(result, errors...)
+			continue
+		}
+
+		if len(files) == 0 {
+			continue
+		}
+
+		if field.Type == fileHeaderType {
+			value.Field(i).Set(reflect.ValueOf(files[0]))
+		} else {
+			value.Field(i).Set(reflect.ValueOf(files))
+		}
+	}
+
+	if err := DefaultValidator.Struct(out); err != nil {
+		result = append(result, ExtractValidatorErrors(err)...)
+	}
+
+	return result
+}
+
+func checkFiles(field string, files []*multipart.FileHeader, config *FileConfig) []ValidationError {
+	if len(files) == 0 {
+		if config.Required {
+			return []ValidationError{{Field: field, Tag: "required"}}
+		}
+
+		return nil
+	}
+
+	if len(files) > config.MaxCount {
+		return []ValidationError{{Field: field, Tag: "maxCount"}}
+	}
+
+	var result []ValidationError
+
+	for i, file := range files {
+		name := field
+		if len(files) > 1 {
+			name = fmt.Sprintf("%s[%d]", field, i)
+		}
+
+		if config.MaxSize > 0 && file.Size > config.MaxSize {
+			result = append(result, ValidationError{Field: name, Tag: "maxSize"})
+			continue
+		}
+
+		if len(config.AllowedTypes) > 0 {
+			contentType, err := detectFileType(file)
+			if err != nil {
+				result = append(result, ValidationError{Field: name, Tag: "format"})
+				continue
+			}
+
+			if !isAllowedType(contentType, config.AllowedTypes) {
+				result = append(result, ValidationError{Field: name, Tag: "type"})
+			}
+		}
+	}
+
+	return result
+}
+
+func detectFileType(file *multipart.FileHeader) (string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := f.Read(head)
+	if err != nil && n == 0 {
+		return "", err
+	}
+
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	return mediaType, err
+}
+
+func isAllowedType(contentType string, allowedTypes []string) bool {
+	for _, allowed := range allowedTypes {
+		allowed = strings.ToLower(allowed)
+
+		if allowed == contentType || allowed == "*/*" {
+			return true
+		}
+		if strings.HasSuffix(allowed, "/*") && strings.HasPrefix(contentType, strings.TrimSuffix(allowed, "*")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseFileTag(tag string) (string, *FileConfig, error) {
+	parts := strings.Split(tag, ",")
+	provided := &FileConfig{}
+
+	for _, option := range parts[1:] {
+		key, value, _ := strings.Cut(option, "=")
+
+		switch key {
+		case "required":
+			provided.Required = true
+		case "maxCount":
+			count, err := strconv.Atoi(value)
+			if err != nil {
+				return "", nil, err
+			}
+			provided.MaxCount = count
+		case "maxSize":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return "", nil, err
+			}
+			provided.MaxSize = size
+		case "types":
+			provided.AllowedTypes = strings.Split(value, "|")
+		default:
+			return "", nil, fmt.Errorf("unknown option %q", key)
+		}
+	}
+
+	return parts[0], provided, nil
+}
+
+func mergeFileConfig(provided *FileConfig) *FileConfig {
+	config := &FileConfig{
+		MaxCount: 1,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.Required {
+		config.Required = true
+	}
+	if provided.MaxCount > 0 {
+		config.MaxCount = provided.MaxCount
+	}
+	if provided.MaxSize > 0 {
+		config.MaxSize = provided.MaxSize
+	}
+	if provided.AllowedTypes != nil {
+		config.AllowedTypes = provided.AllowedTypes
+	}
+
+	return config
+}
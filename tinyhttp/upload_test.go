@@ -0,0 +1,55 @@
+package tinyhttp
+
+import (
+	"bytes"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"mime/multipart"
+	"net/http"
+	"testing"
+)
+
+type uploadRequest struct {
+	Title  string                `form:"title" validate:"required"`
+	Avatar *multipart.FileHeader `file:"avatar,required,types=image/png"`
+}
+
+func TestBindMultipart(t *testing.T) {
+	// given
+	var bound uploadRequest
+	var errors []ValidationError
+
+	app := fiber.New()
+	app.Post("/", func(c *fiber.Ctx) error {
+		bound = uploadRequest{}
+		errors = BindMultipart(c, &bound)
+		return nil
+	})
+
+	send := func(avatar []byte) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		_ = writer.WriteField("title", "profile")
+		part, _ := writer.CreateFormFile("avatar", "avatar.png")
+		_, _ = part.Write(avatar)
+		_ = writer.Close()
+
+		req, _ := http.NewRequest("POST", "/", body)
+		req.Header.Set(fiber.HeaderContentType, writer.FormDataContentType())
+		_, _ = app.Test(req, -1)
+	}
+
+	// when
+	send([]byte("\x89PNG\r\n\x1a\n" + "image data"))
+
+	// then
+	assert.Nil(t, errors, "PNG file should be accepted")
+	assert.Equal(t, "profile", bound.Title)
+	assert.Equal(t, "avatar.png", bound.Avatar.Filename)
+
+	// when
+	send([]byte("<html><body>not an image</body></html>"))
+
+	// then
+	assert.Equal(t, []ValidationError{{Field: "avatar", Tag: "type"}}, errors, "type should be sniffed from content")
+}
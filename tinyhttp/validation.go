@@ -79,6 +79,10 @@ func init() {
 			fieldName = resolveTag(field, "form")
 		}
 
+		if fieldName == "" {
+			fieldName = resolveTag(field, "query")
+		}
+
 		if fieldName == "" {
 			fieldName = resolveTag(field, "header")
 		}
@@ -0,0 +1,266 @@
+package tinyhttp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+)
+
+// WebSocketHandler is a function handling WebSocket connection. The connection is closed when the function returns.
+type WebSocketHandler = func(conn *WebSocketConn)
+
+// WebSocketConfig holds a configuration for WebSocket.
+type WebSocketConfig struct {
+	// ReadLimit is a maximal size of the received message, in bytes (default: 1MB).
+	ReadLimit int64
+
+	// PingInterval is an interval of sending pings to the client (default: 30s).
+	PingInterval time.Duration
+
+	// PongTimeout is a maximal time to wait for the response to ping, before the connection is closed
+	// (default: 60s).
+	PongTimeout time.Duration
+
+	// WriteTimeout is a maximal time of writing a single message (default: 10s).
+	WriteTimeout time.Duration
+
+	// Origins is a list of allowed origins of the requests. All origins are allowed if it's empty (default: empty).
+	Origins []string
+
+	// Subprotocols is a list of supported subprotocols, in order of preference (default: empty).
+	Subprotocols []string
+
+	// EnableCompression enables negotiating per message compression (default: false).
+	EnableCompression bool
+}
+
+// WebSocketConn is a WebSocket connection handled by WebSocketHandler. It's safe to write to it concurrently.
+type WebSocketConn struct {
+	*websocket.Conn
+
+	config     *WebSocketConfig
+	writeLock  sync.Mutex
+	ctx        context.Context
+	cancelFunc context.CancelFunc
+}
+
+var (
+	activeWebSockets     = map[*fiber.App]map[*WebSocketConn]struct{}{}
+	activeWebSocketsLock sync.Mutex
+)
+
+// WebSocket creates a handler upgrading the connection to WebSocket protocol and passing it to given handler.
+// Requests that are not WebSocket upgrade requests are answered with 426 Upgrade Required.
+// Active connections are closed with "going away" status when the server is stopped.
+func WebSocket(handler WebSocketHandler, config ...*WebSocketConfig) fiber.Handler {
+	var providedConfig *WebSocketConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeWebSocketConfig(providedConfig)
+
+	upgrade := websocket.New(
+		func(conn *websocket.Conn) {
+			handleWebSocket(conn, handler, c)
+		},
+		websocket.Config{
+			Origins:           c.Origins,
+			Subprotocols:      c.Subprotocols,
+			EnableCompression: c.EnableCompression,
+		},
+	)
+
+	return func(ctx *fiber.Ctx) error {
+		if !websocket.IsWebSocketUpgrade(ctx) {
+			return fiber.ErrUpgradeRequired
+		}
+
+		ctx.Locals(webSocketAppLocal, ctx.App())
+		return upgrade(ctx)
+	}
+}
+
+const webSocketAppLocal = "tinyhttp.websocketApp"
+
+// Context returns a context cancelled when the connection is closed.
+func (c *WebSocketConn) Context() context.Context {
+	return c.ctx
+}
+
+// ReadJSON reads the next message and unmarshals it from JSON into v.
+func (c *WebSocketConn) ReadJSON(v any) error {
+	_, data, err := c.ReadMessage()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// WriteJSON marshals v to JSON and sends it as a text message.
+func (c *WebSocketConn) WriteJSON(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return c.WriteMessage(websocket.TextMessage, data)
+}
+
+// WriteText sends a text message.
+func (c *WebSocketConn) WriteText(data string) error {
+	return c.WriteMessage(websocket.TextMessage, []byte(data))
+}
+
+// WriteBinary sends a binary message.
+func (c *WebSocketConn) WriteBinary(data []byte) error {
+	return c.WriteMessage(websocket.BinaryMessage, data)
+}
+
+// WriteMessage sends a message of given type.
+func (c *WebSocketConn) WriteMessage(messageType int, data []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	_ = c.Conn.SetWriteDeadline(time.Now().Add(c.config.WriteTimeout))
+	return c.Conn.WriteMessage(messageType, data)
+}
+
+// Close sends a close message with given code and reason and closes the connection.
+func (c *WebSocketConn) Close(code int, reason string) error {
+	c.writeLock.Lock()
+	_ = c.Conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason),
+		time.Now().Add(c.config.WriteTimeout),
+	)
+	c.writeLock.Unlock()
+
+	c.cancelFunc()
+	return c.Conn.Close()
+}
+
+func (c *WebSocketConn) ping() error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	return c.Conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(c.config.WriteTimeout))
+}
+
+func (c *WebSocketConn) keepAlive() {
+	ticker := time.NewTicker(c.config.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.ping(); err != nil {
+				c.cancelFunc()
+				return
+			}
+		}
+	}
+}
+
+func handleWebSocket(conn *websocket.Conn, handler WebSocketHandler, config *WebSocketConfig) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &WebSocketConn{
+		Conn:       conn,
+		config:     config,
+		ctx:        ctx,
+		cancelFunc: cancel,
+	}
+	defer cancel()
+
+	conn.SetReadLimit(config.ReadLimit)
+	_ = conn.SetReadDeadline(time.Now().Add(config.PongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(config.PongTimeout))
+	})
+
+	app, _ := conn.Locals(webSocketAppLocal).(*fiber.App)
+	trackWebSocket(app, c)
+	defer untrackWebSocket(app, c)
+
+	go c.keepAlive()
+
+	handler(c)
+}
+
+func trackWebSocket(app *fiber.App, conn *WebSocketConn) {
+	activeWebSocketsLock.Lock()
+	defer activeWebSocketsLock.Unlock()
+
+	if activeWebSockets[app] == nil {
+		activeWebSockets[app] = map[*WebSocketConn]struct{}{}
+	}
+
+	activeWebSockets[app][conn] = struct{}{}
+}
+
+func untrackWebSocket(app *fiber.App, conn *WebSocketConn) {
+	activeWebSocketsLock.Lock()
+	defer activeWebSocketsLock.Unlock()
+
+	delete(activeWebSockets[app], conn)
+	if len(activeWebSockets[app]) == 0 {
+		delete(activeWebSockets, app)
+	}
+}
+
+// closeWebSockets closes all the WebSocket connections handled by given app.
+func closeWebSockets(app *fiber.App) {
+	activeWebSocketsLock.Lock()
+	var conns []*WebSocketConn
+	for conn := range activeWebSockets[app] {
+		conns = append(conns, conn)
+	}
+	activeWebSocketsLock.Unlock()
+
+	for _, conn := range conns {
+		_ = conn.Close(websocket.CloseGoingAway, "server is shutting down")
+	}
+}
+
+func mergeWebSocketConfig(provided *WebSocketConfig) *WebSocketConfig {
+	config := &WebSocketConfig{
+		ReadLimit:    1024 * 1024,
+		PingInterval: 30 * time.Second,
+		PongTimeout:  60 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.ReadLimit > 0 {
+		config.ReadLimit = provided.ReadLimit
+	}
+	if provided.PingInterval > 0 {
+		config.PingInterval = provided.PingInterval
+	}
+	if provided.PongTimeout > 0 {
+		config.PongTimeout = provided.PongTimeout
+	}
+	if provided.WriteTimeout > 0 {
+		config.WriteTimeout = provided.WriteTimeout
+	}
+	if provided.Origins != nil {
+		config.Origins = provided.Origins
+	}
+	if provided.Subprotocols != nil {
+		config.Subprotocols = provided.Subprotocols
+	}
+	if provided.EnableCompression {
+		config.EnableCompression = true
+	}
+
+	return config
+}
@@ -0,0 +1,67 @@
+package tinyhttp
+
+import (
+	"fmt"
+	fws "github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWebSocketUpgradeRequired(t *testing.T) {
+	// given
+	server := NewServer("address")
+	server.Get("/ws", WebSocket(func(conn *WebSocketConn) {}))
+
+	// when
+	req, _ := http.NewRequest("GET", "/ws", nil)
+	response, err := server.App.Test(req, -1)
+	if err != nil {
+		assert.NoError(t, err)
+		return
+	}
+	defer response.Body.Close()
+
+	// then
+	assert.Equal(t, fiber.StatusUpgradeRequired, response.StatusCode, "response code should be 426")
+}
+
+func TestWebSocketClosedOnStop(t *testing.T) {
+	// given
+	connected := make(chan struct{})
+
+	server := NewServer("127.0.0.1:0")
+	server.Get("/ws", WebSocket(func(conn *WebSocketConn) {
+		close(connected)
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+
+	go func() {
+		_ = server.Start()
+	}()
+	<-server.Ready()
+
+	client, _, err := fws.DefaultDialer.Dial(fmt.Sprintf("ws://127.0.0.1:%d/ws", server.Port()), nil)
+	if err != nil {
+		server.Stop()
+		assert.NoError(t, err)
+		return
+	}
+	defer client.Close()
+	<-connected
+
+	// when
+	server.Stop()
+
+	// then
+	_ = client.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, _, err = client.ReadMessage()
+	assert.True(t, fws.IsCloseError(err, fws.CloseGoingAway), "connection should be closed with going away status")
+}
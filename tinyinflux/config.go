@@ -0,0 +1,89 @@
+package tinyinflux
+
+import (
+	"time"
+
+	"github.com/mkorman9/tiny/tinyhttp/requests"
+)
+
+// WriterConfig holds a configuration for NewWriter.
+type WriterConfig struct {
+	// Org is a name of the organization to write to.
+	Org string
+
+	// Bucket is a name of the bucket to write to.
+	Bucket string
+
+	// Token is an API token used for authorization.
+	Token string
+
+	// BatchSize is a number of buffered points that triggers a flush (default: 5000).
+	BatchSize int
+
+	// MaxBufferSize is a maximum number of points kept in the buffer when the server is unavailable.
+	// When exceeded, the oldest points are dropped (default: 100000).
+	MaxBufferSize int
+
+	// FlushInterval is a time after which the buffer is flushed regardless of its size (default: 1s).
+	FlushInterval time.Duration
+
+	// MaxRetries is a maximum number of times a failed flush is retried before the batch is dropped (default: 3).
+	MaxRetries int
+
+	// RetryDelay is a delay between subsequent retries of a failed flush (default: 1s).
+	RetryDelay time.Duration
+
+	// ShutdownTimeout is a maximum time Stop waits for the buffered points to be flushed (default: 30s).
+	ShutdownTimeout time.Duration
+
+	// Client is a configuration of the underlying HTTP client.
+	Client *requests.Config
+}
+
+func mergeWriterConfig(provided *WriterConfig) *WriterConfig {
+	config := &WriterConfig{
+		BatchSize:       5000,
+		MaxBufferSize:   100000,
+		FlushInterval:   time.Second,
+		MaxRetries:      3,
+		RetryDelay:      time.Second,
+		ShutdownTimeout: 30 * time.Second,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.Org != "" {
+		config.Org = provided.Org
+	}
+	if provided.Bucket != "" {
+		config.Bucket = provided.Bucket
+	}
+	if provided.Token != "" {
+		config.Token = provided.Token
+	}
+	if provided.BatchSize > 0 {
+		config.BatchSize = provided.BatchSize
+	}
+	if provided.MaxBufferSize > 0 {
+		config.MaxBufferSize = provided.MaxBufferSize
+	}
+	if provided.FlushInterval > 0 {
+		config.FlushInterval = provided.FlushInterval
+	}
+	if provided.MaxRetries > 0 {
+		config.MaxRetries = provided.MaxRetries
+	}
+	if provided.RetryDelay > 0 {
+		config.RetryDelay = provided.RetryDelay
+	}
+	if provided.ShutdownTimeout > 0 {
+		config.ShutdownTimeout = provided.ShutdownTimeout
+	}
+	if provided.Client != nil {
+		config.Client = provided.Client
+	}
+
+	return config
+}
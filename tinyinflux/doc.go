@@ -0,0 +1,5 @@
+/*
+Package tinyinflux provides a buffered writer of InfluxDB line protocol points.
+It works with any server accepting the InfluxDB v2 write API, such as InfluxDB or VictoriaMetrics.
+*/
+package tinyinflux
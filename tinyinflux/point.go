@@ -0,0 +1,149 @@
+package tinyinflux
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	keyEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+	stringEscaper      = strings.NewReplacer(`"`, `\"`, `\`, `\\`)
+)
+
+// Point represents a single data point in the line protocol.
+type Point struct {
+	measurement string
+	tags        map[string]string
+	fields      map[string]any
+	time        time.Time
+}
+
+// NewPoint creates new Point for given measurement. Time of the point is set to current time.
+func NewPoint(measurement string) *Point {
+	return &Point{
+		measurement: measurement,
+		tags:        map[string]string{},
+		fields:      map[string]any{},
+		time:        time.Now(),
+	}
+}
+
+// Tag adds a tag to the point.
+func (p *Point) Tag(key, value string) *Point {
+	p.tags[key] = value
+	return p
+}
+
+// Tags adds all the given tags to the point.
+func (p *Point) Tags(tags map[string]string) *Point {
+	for key, value := range tags {
+		p.tags[key] = value
+	}
+
+	return p
+}
+
+// Field adds a field to the point. Supported value types are integers, floats, bool and string.
+func (p *Point) Field(key string, value any) *Point {
+	p.fields[key] = value
+	return p
+}
+
+// Fields adds all the given fields to the point.
+func (p *Point) Fields(fields map[string]any) *Point {
+	for key, value := range fields {
+		p.fields[key] = value
+	}
+
+	return p
+}
+
+// At sets time of the point.
+func (p *Point) At(t time.Time) *Point {
+	p.time = t
+	return p
+}
+
+// String encodes the point to the line protocol.
+func (p *Point) String() string {
+	var builder strings.Builder
+	p.encode(&builder)
+	return builder.String()
+}
+
+func (p *Point) encode(builder *strings.Builder) {
+	builder.WriteString(measurementEscaper.Replace(p.measurement))
+
+	for _, key := range sortedKeys(p.tags) {
+		builder.WriteByte(',')
+		builder.WriteString(keyEscaper.Replace(key))
+		builder.WriteByte('=')
+		builder.WriteString(keyEscaper.Replace(p.tags[key]))
+	}
+
+	builder.WriteByte(' ')
+
+	for i, key := range sortedKeys(p.fields) {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+
+		builder.WriteString(keyEscaper.Replace(key))
+		builder.WriteByte('=')
+		builder.WriteString(formatFieldValue(p.fields[key]))
+	}
+
+	builder.WriteByte(' ')
+	builder.WriteString(strconv.FormatInt(p.time.UnixNano(), 10))
+}
+
+func formatFieldValue(value any) string {
+	switch v := value.(type) {
+	case int:
+		return strconv.FormatInt(int64(v), 10) + "i"
+	case int8:
+		return strconv.FormatInt(int64(v), 10) + "i"
+	case int16:
+		return strconv.FormatInt(int64(v), 10) + "i"
+	case int32:
+		return strconv.FormatInt(int64(v), 10) + "i"
+	case int64:
+		return strconv.FormatInt(v, 10) + "i"
+	case uint:
+		return strconv.FormatUint(uint64(v), 10) + "u"
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10) + "u"
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10) + "u"
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10) + "u"
+	case uint64:
+		return strconv.FormatUint(v, 10) + "u"
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return `"` + stringEscaper.Replace(v) + `"`
+	case time.Duration:
+		return strconv.FormatInt(int64(v), 10) + "i"
+	default:
+		return `"` + stringEscaper.Replace(fmt.Sprintf("%v", v)) + `"`
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
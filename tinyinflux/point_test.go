@@ -0,0 +1,30 @@
+package tinyinflux
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPointEncoding(t *testing.T) {
+	// given
+	point := NewPoint("http requests").
+		Tag("host", "server,1").
+		Tag("env", "prod").
+		Field("count", 10).
+		Field("ratio", 0.5).
+		Field("ok", true).
+		Field("message", `say "hi"`).
+		At(time.Unix(0, 1000))
+
+	// when
+	encoded := point.String()
+
+	// then
+	assert.Equal(
+		t,
+		`http\ requests,env=prod,host=server\,1 count=10i,message="say \"hi\"",ok=true,ratio=0.5 1000`,
+		encoded,
+		"encoded point should match",
+	)
+}
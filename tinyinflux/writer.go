@@ -0,0 +1,211 @@
+package tinyinflux
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mkorman9/tiny/tinyhttp/requests"
+	"github.com/rs/zerolog/log"
+)
+
+// Writer is a Service that buffers points and periodically sends them to the server in batches.
+// Failed batches are retried and all the buffered points are flushed when the service is stopped.
+type Writer struct {
+	url    string
+	config *WriterConfig
+	client *requests.Client
+
+	points       []*Point
+	pointsMutex  sync.Mutex
+	flushChannel chan struct{}
+	stopChannel  chan struct{}
+	stopOnce     sync.Once
+	doneChannel  chan struct{}
+	stateLock    sync.Mutex
+	started      bool
+	stopped      bool
+}
+
+// NewWriter creates new Writer sending points to the server available under given base URL,
+// for example "http://localhost:8086".
+func NewWriter(baseURL string, config ...*WriterConfig) *Writer {
+	var providedConfig *WriterConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeWriterConfig(providedConfig)
+
+	query := url.Values{}
+	query.Set("precision", "ns")
+	if c.Org != "" {
+		query.Set("org", c.Org)
+	}
+	if c.Bucket != "" {
+		query.Set("bucket", c.Bucket)
+	}
+
+	return &Writer{
+		url:          fmt.Sprintf("%s/api/v2/write?%s", strings.TrimSuffix(baseURL, "/"), query.Encode()),
+		config:       c,
+		client:       requests.NewClient(c.Client),
+		flushChannel: make(chan struct{}, 1),
+		stopChannel:  make(chan struct{}),
+		doneChannel:  make(chan struct{}),
+	}
+}
+
+// Write adds given points to the buffer.
+func (w *Writer) Write(points ...*Point) {
+	w.pointsMutex.Lock()
+	w.points = append(w.points, points...)
+	if overflow := len(w.points) - w.config.MaxBufferSize; overflow > 0 {
+		w.points = w.points[overflow:]
+		log.Warn().Msgf("Line protocol buffer is full, dropped %d points", overflow)
+	}
+	full := len(w.points) >= w.config.BatchSize
+	w.pointsMutex.Unlock()
+
+	if full {
+		select {
+		case w.flushChannel <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Start implements the interface of tiny.Service.
+func (w *Writer) Start() error {
+	w.stateLock.Lock()
+	if w.started {
+		w.stateLock.Unlock()
+		return errors.New("writer already started")
+	}
+	if w.stopped {
+		w.stateLock.Unlock()
+		return nil
+	}
+	w.started = true
+	w.stateLock.Unlock()
+
+	defer close(w.doneChannel)
+
+	ticker := time.NewTicker(w.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopChannel:
+			w.flush()
+			return nil
+		case <-ticker.C:
+			w.flush()
+		case <-w.flushChannel:
+			w.flush()
+		}
+	}
+}
+
+// Stop implements the interface of tiny.Service.
+// It waits for the buffered points to be flushed, but no longer than ShutdownTimeout.
+func (w *Writer) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopChannel)
+	})
+
+	w.stateLock.Lock()
+	w.stopped = true
+	started := w.started
+	w.stateLock.Unlock()
+
+	if !started {
+		w.flush()
+		return
+	}
+
+	select {
+	case <-w.doneChannel:
+	case <-time.After(w.config.ShutdownTimeout):
+		log.Error().Msg("Timeout while waiting for the buffered points to be written")
+	}
+}
+
+func (w *Writer) flush() {
+	for {
+		w.pointsMutex.Lock()
+		size := len(w.points)
+		if size > w.config.BatchSize {
+			size = w.config.BatchSize
+		}
+		batch := w.points[:size]
+		w.points = w.points[size:]
+		w.pointsMutex.Unlock()
+
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := w.send(batch); err != nil {
+			log.Error().Err(err).Msgf("Failed to write %d points", len(batch))
+			return
+		}
+	}
+}
+
+func (w *Writer) send(points []*Point) error {
+	var builder strings.Builder
+	for _, point := range points {
+		point.encode(&builder)
+		builder.WriteByte('\n')
+	}
+	body := builder.String()
+
+	var err error
+	for retry := 0; retry <= w.config.MaxRetries; retry++ {
+		if retry > 0 {
+			time.Sleep(w.config.RetryDelay)
+		}
+
+		err = w.post(body)
+		if err == nil {
+			return nil
+		}
+
+		log.Debug().Err(err).Msgf("Write of line protocol batch failed. Retry %d/%d", retry+1, w.config.MaxRetries+1)
+	}
+
+	return err
+}
+
+func (w *Writer) post(body string) error {
+	opts := []requests.RequestOpt{
+		requests.POST,
+		requests.Body(strings.NewReader(body)),
+		requests.ContentType("text/plain; charset=utf-8"),
+	}
+	if w.config.Token != "" {
+		opts = append(opts, requests.Header("Authorization", "Token "+w.config.Token))
+	}
+
+	request, err := requests.NewRequest(w.url, opts...)
+	if err != nil {
+		return err
+	}
+
+	response, err := w.client.Send(request)
+	if err != nil {
+		return err
+	}
+
+	responseBody, _ := requests.ReadResponseBody(response)
+
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d: %s", response.StatusCode, string(responseBody))
+	}
+
+	return nil
+}
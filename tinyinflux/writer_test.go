@@ -0,0 +1,76 @@
+package tinyinflux
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func startLineProtocolServer(delay time.Duration) (*httptest.Server, func() []string) {
+	var (
+		received []string
+		lock     sync.Mutex
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		time.Sleep(delay)
+
+		lock.Lock()
+		received = append(received, string(body))
+		lock.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	return server, func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return received
+	}
+}
+
+func TestWriterFlushesOnStop(t *testing.T) {
+	// given
+	server, received := startLineProtocolServer(100 * time.Millisecond)
+	defer server.Close()
+
+	writer := NewWriter(server.URL, &WriterConfig{FlushInterval: time.Hour})
+
+	started := make(chan struct{})
+	go func() {
+		close(started)
+		_ = writer.Start()
+	}()
+	<-started
+	time.Sleep(10 * time.Millisecond)
+
+	writer.Write(NewPoint("requests").Field("count", 1).At(time.Unix(0, 1)))
+
+	// when
+	writer.Stop()
+
+	// then
+	assert.Equal(t, []string{"requests count=1i 1\n"}, received(), "buffered points should be written before Stop returns")
+}
+
+func TestWriterStopWithoutStart(t *testing.T) {
+	// given
+	server, received := startLineProtocolServer(0)
+	defer server.Close()
+
+	writer := NewWriter(server.URL)
+	writer.Write(NewPoint("requests").Field("count", 1).At(time.Unix(0, 1)))
+
+	// when
+	writer.Stop()
+	err := writer.Start()
+
+	// then
+	assert.NoError(t, err, "start after stop should return immediately")
+	assert.Equal(t, []string{"requests count=1i 1\n"}, received(), "buffered points should be written")
+}
@@ -0,0 +1,100 @@
+package tinyjobs
+
+import "time"
+
+// QueueConfig holds a configuration for NewQueue.
+type QueueConfig struct {
+	// TableName is a name of the table holding pending jobs (default: "jobs").
+	TableName string
+
+	// DeadLetterTableName is a name of the table holding jobs that exceeded their retry limit (default: "jobs_dead").
+	DeadLetterTableName string
+
+	// MaxAttempts is a default maximum number of attempts to process a single job (default: 5).
+	MaxAttempts int
+}
+
+// WorkerConfig holds a configuration for NewWorker.
+type WorkerConfig struct {
+	// Concurrency is a number of jobs processed in parallel (default: 1).
+	Concurrency int
+
+	// PollInterval is a time to wait before querying the queue again after it was found empty (default: 1s).
+	PollInterval time.Duration
+
+	// LockTimeout is a time after which a job claimed by a worker is considered abandoned and can be claimed again
+	// (default: 5m). A handler running longer than LockTimeout does not prevent that, so the job is claimed
+	// and executed again by another worker, while the first execution is still in progress.
+	// LockTimeout should therefore be longer than the longest expected execution of a handler.
+	LockTimeout time.Duration
+
+	// RetryBackoff is a delay before the first retry of a failed job.
+	// Each subsequent retry doubles the delay (default: 5s).
+	RetryBackoff time.Duration
+
+	// MaxRetryBackoff is an upper bound for the delay between retries (default: 1h).
+	MaxRetryBackoff time.Duration
+
+	// ShutdownTimeout is a maximum time to wait for in-flight jobs to finish when stopping the worker (default: 30s).
+	ShutdownTimeout time.Duration
+}
+
+func mergeQueueConfig(provided *QueueConfig) *QueueConfig {
+	config := &QueueConfig{
+		TableName:           "jobs",
+		DeadLetterTableName: "jobs_dead",
+		MaxAttempts:         5,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.TableName != "" {
+		config.TableName = provided.TableName
+	}
+	if provided.DeadLetterTableName != "" {
+		config.DeadLetterTableName = provided.DeadLetterTableName
+	}
+	if provided.MaxAttempts > 0 {
+		config.MaxAttempts = provided.MaxAttempts
+	}
+
+	return config
+}
+
+func mergeWorkerConfig(provided *WorkerConfig) *WorkerConfig {
+	config := &WorkerConfig{
+		Concurrency:     1,
+		PollInterval:    time.Second,
+		LockTimeout:     5 * time.Minute,
+		RetryBackoff:    5 * time.Second,
+		MaxRetryBackoff: time.Hour,
+		ShutdownTimeout: 30 * time.Second,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.Concurrency > 0 {
+		config.Concurrency = provided.Concurrency
+	}
+	if provided.PollInterval > 0 {
+		config.PollInterval = provided.PollInterval
+	}
+	if provided.LockTimeout > 0 {
+		config.LockTimeout = provided.LockTimeout
+	}
+	if provided.RetryBackoff > 0 {
+		config.RetryBackoff = provided.RetryBackoff
+	}
+	if provided.MaxRetryBackoff > 0 {
+		config.MaxRetryBackoff = provided.MaxRetryBackoff
+	}
+	if provided.ShutdownTimeout > 0 {
+		config.ShutdownTimeout = provided.ShutdownTimeout
+	}
+
+	return config
+}
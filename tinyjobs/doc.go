@@ -0,0 +1,4 @@
+/*
+Package tinyjobs provides a persistent job queue backed by Postgres.
+*/
+package tinyjobs
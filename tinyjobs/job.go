@@ -0,0 +1,71 @@
+package tinyjobs
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Job represents a single unit of work stored in the queue.
+type Job struct {
+	// ID is a unique identifier of the job.
+	ID int64 `gorm:"primaryKey"`
+
+	// Name is a name of the job, used to select a handler.
+	Name string `gorm:"not null;index"`
+
+	// Payload is a JSON-encoded payload passed to Enqueue.
+	Payload []byte
+
+	// UniqueKey is an optional key preventing the same job from being enqueued twice while it's still pending.
+	UniqueKey *string `gorm:"uniqueIndex"`
+
+	// Attempts is a number of times the job has been claimed by a worker.
+	Attempts int `gorm:"not null"`
+
+	// MaxAttempts is a maximum number of attempts before the job is moved to the dead-letter table.
+	MaxAttempts int `gorm:"not null"`
+
+	// RunAt is the earliest time the job can be processed.
+	RunAt time.Time `gorm:"not null;index"`
+
+	// LockedUntil is set when the job is claimed by a worker.
+	LockedUntil *time.Time
+
+	// LastError is an error message returned by the most recent failed attempt.
+	LastError string
+
+	// CreatedAt is a time the job has been enqueued.
+	CreatedAt time.Time
+}
+
+// DeadJob represents a job that exceeded its maximum number of attempts.
+type DeadJob struct {
+	// ID is an identifier the job had in the queue.
+	ID int64 `gorm:"primaryKey;autoIncrement:false"`
+
+	// Name is a name of the job.
+	Name string `gorm:"not null;index"`
+
+	// Payload is a JSON-encoded payload passed to Enqueue.
+	Payload []byte
+
+	// UniqueKey is a unique key the job was enqueued with.
+	UniqueKey *string
+
+	// Attempts is a number of times the job has been attempted.
+	Attempts int `gorm:"not null"`
+
+	// LastError is an error message returned by the last attempt.
+	LastError string
+
+	// CreatedAt is a time the job has been enqueued.
+	CreatedAt time.Time
+
+	// FailedAt is a time the job has been moved to the dead-letter table.
+	FailedAt time.Time
+}
+
+// Bind decodes job's payload into given value.
+func (j *Job) Bind(out any) error {
+	return json.Unmarshal(j.Payload, out)
+}
@@ -0,0 +1,204 @@
+package tinyjobs
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrDuplicateJob is returned by Enqueue when a job with the same unique key is still pending.
+	ErrDuplicateJob = errors.New("job with given unique key is already enqueued")
+
+	errNoJobs = errors.New("no jobs available")
+)
+
+// Queue is a persistent job queue stored in Postgres tables.
+type Queue struct {
+	db     *gorm.DB
+	config *QueueConfig
+}
+
+// EnqueueConfig holds a configuration for a single call to Enqueue.
+type EnqueueConfig struct {
+	runAt       time.Time
+	maxAttempts int
+	uniqueKey   *string
+}
+
+// EnqueueOpt is an option to be specified to Enqueue.
+type EnqueueOpt = func(*EnqueueConfig)
+
+// NewQueue creates new Queue using given database connection.
+func NewQueue(db *gorm.DB, config ...*QueueConfig) *Queue {
+	var providedConfig *QueueConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeQueueConfig(providedConfig)
+
+	return &Queue{
+		db:     db,
+		config: c,
+	}
+}
+
+// Migrate creates or updates the jobs and the dead-letter tables.
+func (q *Queue) Migrate() error {
+	if err := q.db.Table(q.config.TableName).AutoMigrate(&Job{}); err != nil {
+		return err
+	}
+
+	return q.db.Table(q.config.DeadLetterTableName).AutoMigrate(&DeadJob{})
+}
+
+// Enqueue adds a new job with given name to the queue. Payload is encoded to JSON.
+// Returns ErrDuplicateJob if the job has been enqueued with Unique option and such a job is still pending.
+func (q *Queue) Enqueue(name string, payload any, opts ...EnqueueOpt) (*Job, error) {
+	config := &EnqueueConfig{
+		runAt:       time.Now().UTC(),
+		maxAttempts: q.config.MaxAttempts,
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	encodedPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &Job{
+		Name:        name,
+		Payload:     encodedPayload,
+		UniqueKey:   config.uniqueKey,
+		MaxAttempts: config.maxAttempts,
+		RunAt:       config.runAt,
+	}
+
+	result := q.db.Table(q.config.TableName).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(job)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrDuplicateJob
+	}
+
+	return job, nil
+}
+
+// Delay postpones the execution of the job by given duration.
+func Delay(delay time.Duration) EnqueueOpt {
+	return func(config *EnqueueConfig) {
+		config.runAt = time.Now().UTC().Add(delay)
+	}
+}
+
+// RunAt postpones the execution of the job until given time.
+func RunAt(t time.Time) EnqueueOpt {
+	return func(config *EnqueueConfig) {
+		config.runAt = t.UTC()
+	}
+}
+
+// MaxAttempts overwrites the maximum number of attempts for the job.
+func MaxAttempts(maxAttempts int) EnqueueOpt {
+	return func(config *EnqueueConfig) {
+		config.maxAttempts = maxAttempts
+	}
+}
+
+// Unique makes sure only one job with given key can be pending at a time.
+func Unique(key string) EnqueueOpt {
+	return func(config *EnqueueConfig) {
+		config.uniqueKey = &key
+	}
+}
+
+func (q *Queue) claim(names []string, lockTimeout time.Duration) (*Job, error) {
+	var job Job
+
+	err := q.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UTC()
+
+		result := tx.Table(q.config.TableName).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("name IN ?", names).
+			Where("run_at <= ?", now).
+			Where("(locked_until IS NULL OR locked_until < ?)", now).
+			Order("run_at").
+			Limit(1).
+			Find(&job)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errNoJobs
+		}
+
+		lockedUntil := now.Add(lockTimeout)
+		job.LockedUntil = &lockedUntil
+		job.Attempts++
+
+		return tx.Table(q.config.TableName).
+			Where("id = ?", job.ID).
+			Updates(map[string]any{
+				"locked_until": job.LockedUntil,
+				"attempts":     job.Attempts,
+			}).
+			Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (q *Queue) complete(job *Job) error {
+	return q.db.Table(q.config.TableName).
+		Where("id = ?", job.ID).
+		Delete(&Job{}).
+		Error
+}
+
+func (q *Queue) retry(job *Job, reason error, runAt time.Time) error {
+	return q.db.Table(q.config.TableName).
+		Where("id = ?", job.ID).
+		Updates(map[string]any{
+			"run_at":       runAt,
+			"locked_until": nil,
+			"last_error":   reason.Error(),
+		}).
+		Error
+}
+
+func (q *Queue) bury(job *Job, reason error) error {
+	return q.db.Transaction(func(tx *gorm.DB) error {
+		deadJob := &DeadJob{
+			ID:        job.ID,
+			Name:      job.Name,
+			Payload:   job.Payload,
+			UniqueKey: job.UniqueKey,
+			Attempts:  job.Attempts,
+			LastError: reason.Error(),
+			CreatedAt: job.CreatedAt,
+			FailedAt:  time.Now().UTC(),
+		}
+
+		if err := tx.Table(q.config.DeadLetterTableName).Create(deadJob).Error; err != nil {
+			return err
+		}
+
+		return tx.Table(q.config.TableName).
+			Where("id = ?", job.ID).
+			Delete(&Job{}).
+			Error
+	})
+}
@@ -0,0 +1,181 @@
+package tinyjobs
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// JobHandler is a function processing jobs of a given name.
+// Returning a non-nil error schedules the job for retry.
+type JobHandler = func(job *Job) error
+
+// jobStore is a storage of jobs used by Worker, implemented by Queue.
+type jobStore interface {
+	claim(names []string, lockTimeout time.Duration) (*Job, error)
+	complete(job *Job) error
+	retry(job *Job, reason error, runAt time.Time) error
+	bury(job *Job, reason error) error
+}
+
+// Worker is a Service that claims jobs from the Queue and passes them to registered handlers.
+type Worker struct {
+	queue       jobStore
+	config      *WorkerConfig
+	handlers    map[string]JobHandler
+	stopChannel chan struct{}
+	stopOnce    sync.Once
+	wg          sync.WaitGroup
+}
+
+// NewWorker creates new Worker consuming jobs from given Queue.
+func NewWorker(queue *Queue, config ...*WorkerConfig) *Worker {
+	var providedConfig *WorkerConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeWorkerConfig(providedConfig)
+
+	return &Worker{
+		queue:       queue,
+		config:      c,
+		handlers:    map[string]JobHandler{},
+		stopChannel: make(chan struct{}),
+	}
+}
+
+// Handle registers a handler for jobs with given name. Handlers must be registered before calling Start.
+func (w *Worker) Handle(name string, handler JobHandler) {
+	w.handlers[name] = handler
+}
+
+// Start implements the interface of tiny.Service.
+func (w *Worker) Start() error {
+	if len(w.handlers) == 0 {
+		return errors.New("no job handlers registered")
+	}
+
+	var names []string
+	for name := range w.handlers {
+		names = append(names, name)
+	}
+
+	log.Info().Msgf("Job worker started (%d)", w.config.Concurrency)
+
+	w.wg.Add(w.config.Concurrency)
+	for i := 0; i < w.config.Concurrency; i++ {
+		go w.loop(names)
+	}
+
+	w.wg.Wait()
+	return nil
+}
+
+// Stop implements the interface of tiny.Service.
+// It waits for in-flight jobs to finish, but no longer than ShutdownTimeout.
+func (w *Worker) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopChannel)
+	})
+
+	drained := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		log.Info().Msg("Job worker stopped")
+	case <-time.After(w.config.ShutdownTimeout):
+		log.Error().Msg("Timeout while waiting for in-flight jobs to finish")
+	}
+}
+
+func (w *Worker) loop(names []string) {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.stopChannel:
+			return
+		default:
+		}
+
+		job, err := w.queue.claim(names, w.config.LockTimeout)
+		if err != nil {
+			if !errors.Is(err, errNoJobs) {
+				log.Error().Err(err).Msg("Failed to claim a job")
+			}
+
+			select {
+			case <-w.stopChannel:
+				return
+			case <-time.After(w.config.PollInterval):
+			}
+
+			continue
+		}
+
+		w.process(job)
+	}
+}
+
+func (w *Worker) process(job *Job) {
+	err := w.runHandler(job)
+	if err == nil {
+		if err := w.queue.complete(job); err != nil {
+			log.Error().Err(err).Msgf("Failed to mark job %d as completed", job.ID)
+		}
+
+		return
+	}
+
+	if job.Attempts >= job.MaxAttempts {
+		log.Error().Err(err).Msgf("Job %d (%s) failed permanently after %d attempts", job.ID, job.Name, job.Attempts)
+
+		if err := w.queue.bury(job, err); err != nil {
+			log.Error().Err(err).Msgf("Failed to move job %d to the dead-letter table", job.ID)
+		}
+
+		return
+	}
+
+	runAt := time.Now().UTC().Add(w.backoff(job.Attempts))
+	log.Warn().Err(err).Msgf("Job %d (%s) failed, retrying at %v", job.ID, job.Name, runAt)
+
+	if err := w.queue.retry(job, err, runAt); err != nil {
+		log.Error().Err(err).Msgf("Failed to schedule retry of job %d", job.ID)
+	}
+}
+
+func (w *Worker) runHandler(job *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+
+			log.Error().
+				Stack().
+				Err(err).
+				Msgf("Panic inside a handler of job %s", job.Name)
+		}
+	}()
+
+	return w.handlers[job.Name](job)
+}
+
+func (w *Worker) backoff(attempt int) time.Duration {
+	delay := w.config.RetryBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+
+		if delay >= w.config.MaxRetryBackoff {
+			return w.config.MaxRetryBackoff
+		}
+	}
+
+	return delay
+}
@@ -0,0 +1,96 @@
+package tinyjobs
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type fakeStore struct {
+	completed []*Job
+	retried   []*Job
+	retryAt   time.Time
+	buried    []*Job
+}
+
+func (s *fakeStore) claim(_ []string, _ time.Duration) (*Job, error) {
+	return nil, errNoJobs
+}
+
+func (s *fakeStore) complete(job *Job) error {
+	s.completed = append(s.completed, job)
+	return nil
+}
+
+func (s *fakeStore) retry(job *Job, _ error, runAt time.Time) error {
+	s.retried = append(s.retried, job)
+	s.retryAt = runAt
+	return nil
+}
+
+func (s *fakeStore) bury(job *Job, _ error) error {
+	s.buried = append(s.buried, job)
+	return nil
+}
+
+func TestBackoff(t *testing.T) {
+	// given
+	worker := NewWorker(nil, &WorkerConfig{
+		RetryBackoff:    time.Second,
+		MaxRetryBackoff: 10 * time.Second,
+	})
+
+	// when
+	delays := []time.Duration{
+		worker.backoff(1),
+		worker.backoff(2),
+		worker.backoff(3),
+		worker.backoff(4),
+		worker.backoff(5),
+		worker.backoff(100),
+	}
+
+	// then
+	assert.Equal(
+		t,
+		[]time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second},
+		delays,
+		"delay should double with each attempt and be capped at MaxRetryBackoff",
+	)
+}
+
+func TestProcess(t *testing.T) {
+	// given
+	store := &fakeStore{}
+	worker := NewWorker(nil, &WorkerConfig{RetryBackoff: time.Minute})
+	worker.queue = store
+
+	worker.Handle("ok", func(job *Job) error {
+		return nil
+	})
+	worker.Handle("failing", func(job *Job) error {
+		return errors.New("failure")
+	})
+	worker.Handle("panicking", func(job *Job) error {
+		panic("failure")
+	})
+
+	succeeded := &Job{ID: 1, Name: "ok", Attempts: 1, MaxAttempts: 3}
+	retried := &Job{ID: 2, Name: "failing", Attempts: 1, MaxAttempts: 3}
+	buried := &Job{ID: 3, Name: "failing", Attempts: 3, MaxAttempts: 3}
+	panicked := &Job{ID: 4, Name: "panicking", Attempts: 3, MaxAttempts: 3}
+
+	// when
+	before := time.Now().UTC()
+	worker.process(succeeded)
+	worker.process(retried)
+	worker.process(buried)
+	worker.process(panicked)
+
+	// then
+	assert.Equal(t, []*Job{succeeded}, store.completed, "successful job should be completed")
+	assert.Equal(t, []*Job{retried}, store.retried, "failed job below the limit should be retried")
+	assert.WithinDuration(t, before.Add(time.Minute), store.retryAt, time.Second, "retry should be delayed by backoff")
+	assert.Equal(t, []*Job{buried, panicked}, store.buried, "job reaching the limit should be buried")
+}
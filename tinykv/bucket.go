@@ -0,0 +1,175 @@
+package tinykv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// headerSize is a size of the expiration timestamp stored in front of each value.
+const headerSize = 8
+
+// Bucket is a typed view of a single bucket in the Store. Values are stored as JSON.
+type Bucket[T any] struct {
+	store *Store
+	name  []byte
+}
+
+// NewBucket creates new Bucket with given name. The bucket is created lazily on the first write.
+func NewBucket[T any](store *Store, name string) *Bucket[T] {
+	store.registerBucket(name)
+
+	return &Bucket[T]{
+		store: store,
+		name:  []byte(name),
+	}
+}
+
+// Get returns the value stored under given key. Returns ErrNotFound if the key does not exist or has expired.
+func (b *Bucket[T]) Get(key string) (T, error) {
+	var value T
+	var found bool
+
+	err := b.store.view(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.name)
+		if bucket == nil {
+			return nil
+		}
+
+		data := bucket.Get([]byte(key))
+		if data == nil || isExpired(data, time.Now()) {
+			return nil
+		}
+
+		found = true
+		return json.Unmarshal(data[headerSize:], &value)
+	})
+	if err != nil {
+		return value, err
+	}
+	if !found {
+		return value, ErrNotFound
+	}
+
+	return value, nil
+}
+
+// Put stores the value under given key. The key expires after optional ttl.
+func (b *Bucket[T]) Put(key string, value T, ttl ...time.Duration) error {
+	var expiresAt time.Time
+	if ttl != nil && ttl[0] > 0 {
+		expiresAt = time.Now().Add(ttl[0])
+	}
+
+	data, err := encodeValue(value, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	return b.store.update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(b.name)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put([]byte(key), data)
+	})
+}
+
+// Delete removes the value stored under given key. Deleting a key that does not exist is a no-op.
+func (b *Bucket[T]) Delete(key string) error {
+	return b.store.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.name)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.Delete([]byte(key))
+	})
+}
+
+// ForEach calls fn for each non-expired entry of the bucket, in the order of keys.
+// Returning an error from fn stops the iteration and the error is returned from ForEach.
+func (b *Bucket[T]) ForEach(fn func(key string, value T) error) error {
+	return b.ForEachPrefix("", fn)
+}
+
+// ForEachPrefix calls fn for each non-expired entry of the bucket with key starting with given prefix,
+// in the order of keys.
+// Returning an error from fn stops the iteration and the error is returned from ForEachPrefix.
+func (b *Bucket[T]) ForEachPrefix(prefix string, fn func(key string, value T) error) error {
+	now := time.Now()
+
+	return b.store.view(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.name)
+		if bucket == nil {
+			return nil
+		}
+
+		cursor := bucket.Cursor()
+		prefixBytes := []byte(prefix)
+
+		for key, data := cursor.Seek(prefixBytes); key != nil && bytes.HasPrefix(key, prefixBytes); key, data = cursor.Next() {
+			if data == nil || isExpired(data, now) {
+				continue
+			}
+
+			var value T
+			if err := json.Unmarshal(data[headerSize:], &value); err != nil {
+				return err
+			}
+
+			if err := fn(string(key), value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Keys returns all non-expired keys of the bucket starting with optional prefix.
+func (b *Bucket[T]) Keys(prefix ...string) ([]string, error) {
+	var p string
+	if prefix != nil {
+		p = prefix[0]
+	}
+
+	var keys []string
+	err := b.ForEachPrefix(p, func(key string, _ T) error {
+		keys = append(keys, key)
+		return nil
+	})
+
+	return keys, err
+}
+
+func encodeValue(value any, expiresAt time.Time) ([]byte, error) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiration int64
+	if !expiresAt.IsZero() {
+		expiration = expiresAt.UnixNano()
+	}
+
+	data := make([]byte, headerSize+len(payload))
+	binary.BigEndian.PutUint64(data, uint64(expiration))
+	copy(data[headerSize:], payload)
+
+	return data, nil
+}
+
+func isExpired(data []byte, now time.Time) bool {
+	if len(data) < headerSize {
+		return true
+	}
+
+	expiration := int64(binary.BigEndian.Uint64(data))
+	return expiration != 0 && expiration <= now.UnixNano()
+}
@@ -0,0 +1,37 @@
+package tinykv
+
+import (
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type session struct {
+	User string `json:"user"`
+}
+
+func TestBucketExpiration(t *testing.T) {
+	// given
+	store := NewStore(filepath.Join(t.TempDir(), "data.db"))
+	err := store.Open()
+	assert.Nil(t, err, "store should be opened")
+	defer store.Close()
+
+	sessions := NewBucket[session](store, "sessions")
+
+	// when
+	_ = sessions.Put("session:1", session{User: "alice"})
+	_ = sessions.Put("session:2", session{User: "bob"}, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	first, firstErr := sessions.Get("session:1")
+	_, secondErr := sessions.Get("session:2")
+	keys, _ := sessions.Keys("session:")
+
+	// then
+	assert.Nil(t, firstErr, "first session should be found")
+	assert.Equal(t, "alice", first.User, "first session should be decoded")
+	assert.ErrorIs(t, secondErr, ErrNotFound, "second session should expire")
+	assert.Equal(t, []string{"session:1"}, keys, "only non-expired keys should be listed")
+}
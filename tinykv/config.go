@@ -0,0 +1,56 @@
+package tinykv
+
+import (
+	"os"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Config holds a configuration for NewStore.
+type Config struct {
+	// FileMode is a mode of the database file created when it doesn't exist (default: 0600).
+	FileMode os.FileMode
+
+	// OpenTimeout is a maximum time spent on waiting for the file lock when opening the database (default: 5s).
+	OpenTimeout time.Duration
+
+	// NoSync disables fsync after each commit. Improves write performance at the cost of durability (default: false).
+	NoSync bool
+
+	// SweepInterval is a time between subsequent removals of expired keys (default: 1m).
+	SweepInterval time.Duration
+
+	// BoltOpt allows to specify a function that operates directly on *bolt.Options.
+	BoltOpt func(*bolt.Options)
+}
+
+func mergeConfig(provided *Config) *Config {
+	config := &Config{
+		FileMode:      0600,
+		OpenTimeout:   5 * time.Second,
+		SweepInterval: time.Minute,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.FileMode != 0 {
+		config.FileMode = provided.FileMode
+	}
+	if provided.OpenTimeout > 0 {
+		config.OpenTimeout = provided.OpenTimeout
+	}
+	if provided.NoSync {
+		config.NoSync = true
+	}
+	if provided.SweepInterval > 0 {
+		config.SweepInterval = provided.SweepInterval
+	}
+	if provided.BoltOpt != nil {
+		config.BoltOpt = provided.BoltOpt
+	}
+
+	return config
+}
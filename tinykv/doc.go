@@ -0,0 +1,4 @@
+/*
+Package tinykv provides an embedded key-value store based on bbolt, with typed buckets and TTL support.
+*/
+package tinykv
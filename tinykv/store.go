@@ -0,0 +1,302 @@
+package tinykv
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ErrNotFound is returned when the key does not exist or has expired.
+var ErrNotFound = errors.New("key not found")
+
+// ErrNotOpen is returned when the store is used before being opened.
+var ErrNotOpen = errors.New("store is not open")
+
+// Store is an embedded key-value store persisted in a single file.
+// Store implements the interface of tiny.Service - it's opened on start, and synced and closed on stop.
+// It can also be opened earlier with Open, if the data needs to be accessed before the services are started.
+type Store struct {
+	path         string
+	config       *Config
+	db           *bolt.DB
+	dbMutex      sync.RWMutex
+	buckets      map[string]struct{}
+	bucketsMutex sync.Mutex
+	stopChannel  chan struct{}
+}
+
+// NewStore creates new Store backed by the file under given path.
+func NewStore(path string, config ...*Config) *Store {
+	var providedConfig *Config
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeConfig(providedConfig)
+
+	return &Store{
+		path:        path,
+		config:      c,
+		buckets:     map[string]struct{}{},
+		stopChannel: make(chan struct{}, 1),
+	}
+}
+
+// Open opens the underlying database file. Calling Open on an already opened store is a no-op.
+func (s *Store) Open() error {
+	s.dbMutex.Lock()
+	defer s.dbMutex.Unlock()
+
+	if s.db != nil {
+		return nil
+	}
+
+	return s.open()
+}
+
+// Close syncs and closes the underlying database file.
+func (s *Store) Close() error {
+	s.dbMutex.Lock()
+	defer s.dbMutex.Unlock()
+
+	return s.close()
+}
+
+// Start implements the interface of tiny.Service.
+func (s *Store) Start() error {
+	if err := s.Open(); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("Key-value store opened (%s)", s.path)
+
+	ticker := time.NewTicker(s.config.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopChannel:
+			return nil
+		case <-ticker.C:
+			if err := s.sweep(); err != nil {
+				log.Error().Err(err).Msg("Failed to remove expired keys from the key-value store")
+			}
+		}
+	}
+}
+
+// Stop implements the interface of tiny.Service.
+func (s *Store) Stop() {
+	if err := s.Close(); err != nil {
+		log.Error().Err(err).Msgf("Failed to close key-value store (%s)", s.path)
+	} else {
+		log.Info().Msgf("Key-value store closed (%s)", s.path)
+	}
+
+	select {
+	case s.stopChannel <- struct{}{}:
+	default:
+	}
+}
+
+// DB returns the underlying *bolt.DB, or nil if the store is not open.
+func (s *Store) DB() *bolt.DB {
+	s.dbMutex.RLock()
+	defer s.dbMutex.RUnlock()
+
+	return s.db
+}
+
+// Backup writes a consistent snapshot of the whole database to w.
+// Backup does not block writers.
+func (s *Store) Backup(w io.Writer) error {
+	return s.view(func(tx *bolt.Tx) error {
+		_, err := tx.WriteTo(w)
+		return err
+	})
+}
+
+// BackupToFile writes a consistent snapshot of the whole database to a file under given path.
+func (s *Store) BackupToFile(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, s.config.FileMode)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Backup(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// Restore replaces the contents of the store with a snapshot created by Backup.
+// All the reads and writes are blocked until the restore completes.
+func (s *Store) Restore(r io.Reader) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".restore-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tempFile.Name())
+	}()
+
+	if _, err := io.Copy(tempFile, r); err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+	if err := tempFile.Sync(); err != nil {
+		_ = tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	s.dbMutex.Lock()
+	defer s.dbMutex.Unlock()
+
+	wasOpen := s.db != nil
+	if err := s.close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tempFile.Name(), s.path); err != nil {
+		return err
+	}
+	if err := os.Chmod(s.path, s.config.FileMode); err != nil {
+		return err
+	}
+
+	if wasOpen {
+		return s.open()
+	}
+
+	return nil
+}
+
+// RestoreFromFile replaces the contents of the store with a snapshot stored under given path.
+func (s *Store) RestoreFromFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return s.Restore(file)
+}
+
+func (s *Store) open() error {
+	options := &bolt.Options{
+		Timeout: s.config.OpenTimeout,
+		NoSync:  s.config.NoSync,
+	}
+	if s.config.BoltOpt != nil {
+		s.config.BoltOpt(options)
+	}
+
+	db, err := bolt.Open(s.path, s.config.FileMode, options)
+	if err != nil {
+		return err
+	}
+
+	s.db = db
+	return nil
+}
+
+func (s *Store) close() error {
+	if s.db == nil {
+		return nil
+	}
+
+	if err := s.db.Sync(); err != nil {
+		log.Warn().Err(err).Msgf("Failed to sync key-value store (%s)", s.path)
+	}
+
+	err := s.db.Close()
+	s.db = nil
+	return err
+}
+
+func (s *Store) view(fn func(tx *bolt.Tx) error) error {
+	s.dbMutex.RLock()
+	defer s.dbMutex.RUnlock()
+
+	if s.db == nil {
+		return ErrNotOpen
+	}
+
+	return s.db.View(fn)
+}
+
+func (s *Store) update(fn func(tx *bolt.Tx) error) error {
+	s.dbMutex.RLock()
+	defer s.dbMutex.RUnlock()
+
+	if s.db == nil {
+		return ErrNotOpen
+	}
+
+	return s.db.Update(fn)
+}
+
+func (s *Store) registerBucket(name string) {
+	s.bucketsMutex.Lock()
+	defer s.bucketsMutex.Unlock()
+
+	s.buckets[name] = struct{}{}
+}
+
+func (s *Store) sweep() error {
+	now := time.Now()
+
+	s.bucketsMutex.Lock()
+	names := make([]string, 0, len(s.buckets))
+	for name := range s.buckets {
+		names = append(names, name)
+	}
+	s.bucketsMutex.Unlock()
+
+	return s.update(func(tx *bolt.Tx) error {
+		for _, name := range names {
+			bucket := tx.Bucket([]byte(name))
+			if bucket == nil {
+				continue
+			}
+
+			var expiredKeys [][]byte
+
+			err := bucket.ForEach(func(key, value []byte) error {
+				if value != nil && isExpired(value, now) {
+					expiredKeys = append(expiredKeys, key)
+				}
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, key := range expiredKeys {
+				if err := bucket.Delete(key); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,280 @@
+package tinyldap
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+var (
+	// ErrInvalidCredentials is returned by Authenticate when the user does not exist or the password is wrong.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// User represents an authenticated directory user.
+type User struct {
+	// DN is a distinguished name of the user entry.
+	DN string
+
+	// Username is a name the user has authenticated with.
+	Username string
+
+	// Attributes holds attributes of the user entry.
+	Attributes map[string][]string
+
+	// Groups is a list of names of the groups the user is a member of.
+	Groups []string
+}
+
+// Client is a pooled LDAP client. Connections are bound as the service account and re-established when broken.
+type Client struct {
+	url    string
+	config *Config
+	pool   chan *ldap.Conn
+}
+
+// Dial creates new Client for the LDAP server available under given URL (ldap:// or ldaps://),
+// and verifies that the service account is able to bind.
+func Dial(url string, config ...*Config) (*Client, error) {
+	var providedConfig *Config
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeConfig(providedConfig)
+
+	if url == "" {
+		return nil, errors.New("URL cannot be empty")
+	}
+
+	client := &Client{
+		url:    url,
+		config: c,
+		pool:   make(chan *ldap.Conn, c.PoolSize),
+	}
+
+	conn, err := client.connect()
+	if err != nil {
+		return nil, err
+	}
+	client.release(conn)
+
+	return client, nil
+}
+
+// Search executes given search request using a connection bound as the service account.
+func (c *Client) Search(request *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	var result *ldap.SearchResult
+
+	err := c.withConn(func(conn *ldap.Conn) error {
+		r, err := conn.Search(request)
+		result = r
+		return err
+	})
+
+	return result, err
+}
+
+// FindUser searches for the user with given username, using the UserFilter.
+// Returns nil if the user does not exist.
+func (c *Client) FindUser(username string) (*ldap.Entry, error) {
+	result, err := c.Search(ldap.NewSearchRequest(
+		c.config.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2,
+		int(c.config.Timeout.Seconds()),
+		false,
+		strings.ReplaceAll(c.config.UserFilter, "%s", ldap.EscapeFilter(username)),
+		c.config.UserAttributes,
+		nil,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Entries) != 1 {
+		return nil, nil
+	}
+
+	return result.Entries[0], nil
+}
+
+// Groups returns names of the groups the entry with given DN is a member of, using the GroupFilter.
+func (c *Client) Groups(dn string) ([]string, error) {
+	escapedDN := ldap.EscapeFilter(dn)
+
+	result, err := c.Search(ldap.NewSearchRequest(
+		c.config.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		int(c.config.Timeout.Seconds()),
+		false,
+		strings.ReplaceAll(c.config.GroupFilter, "%s", escapedDN),
+		[]string{c.config.GroupNameAttribute},
+		nil,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []string
+	for _, entry := range result.Entries {
+		groups = append(groups, entry.GetAttributeValue(c.config.GroupNameAttribute))
+	}
+
+	return groups, nil
+}
+
+// Authenticate verifies the password of the user by binding as the user,
+// and returns the user entry together with the names of its groups.
+// Returns ErrInvalidCredentials if the user does not exist or the password is incorrect.
+func (c *Client) Authenticate(username, password string) (*User, error) {
+	if username == "" || password == "" {
+		// empty password would result in an unauthenticated bind, which always succeeds
+		return nil, ErrInvalidCredentials
+	}
+
+	entry, err := c.FindUser(username)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	err = conn.Bind(entry.DN, password)
+	conn.Close()
+
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+
+		return nil, err
+	}
+
+	groups, err := c.Groups(entry.DN)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := map[string][]string{}
+	for _, attribute := range entry.Attributes {
+		attributes[attribute.Name] = attribute.Values
+	}
+
+	return &User{
+		DN:         entry.DN,
+		Username:   username,
+		Attributes: attributes,
+		Groups:     groups,
+	}, nil
+}
+
+// Close closes all the pooled connections.
+func (c *Client) Close() {
+	for {
+		select {
+		case conn := <-c.pool:
+			conn.Close()
+		default:
+			return
+		}
+	}
+}
+
+func (c *Client) withConn(action func(conn *ldap.Conn) error) error {
+	conn, err := c.acquire()
+	if err != nil {
+		return err
+	}
+
+	err = action(conn)
+	if err != nil && ldap.IsErrorWithCode(err, ldap.ErrorNetwork) {
+		// pooled connection might have been closed by the server, retry once using a fresh one
+		conn.Close()
+
+		conn, err = c.connect()
+		if err != nil {
+			return err
+		}
+
+		err = action(conn)
+	}
+
+	c.release(conn)
+	return err
+}
+
+func (c *Client) acquire() (*ldap.Conn, error) {
+	for {
+		select {
+		case conn := <-c.pool:
+			if conn.IsClosing() {
+				continue
+			}
+
+			return conn, nil
+		default:
+			return c.connect()
+		}
+	}
+}
+
+func (c *Client) release(conn *ldap.Conn) {
+	if conn.IsClosing() {
+		return
+	}
+
+	select {
+	case c.pool <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+func (c *Client) connect() (*ldap.Conn, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.config.BindDN != "" {
+		if err := conn.Bind(c.config.BindDN, c.config.BindPassword); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+func (c *Client) dial() (*ldap.Conn, error) {
+	conn, err := ldap.DialURL(
+		c.url,
+		ldap.DialWithDialer(&net.Dialer{Timeout: c.config.DialTimeout}),
+		ldap.DialWithTLSConfig(c.config.TLSConfig),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetTimeout(c.config.Timeout)
+
+	if c.config.StartTLS {
+		if err := conn.StartTLS(c.config.TLSConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,102 @@
+package tinyldap
+
+import (
+	"crypto/tls"
+	"time"
+)
+
+// Config holds a configuration for Dial.
+type Config struct {
+	// BindDN is a DN of the service account used for searching the directory.
+	BindDN string
+
+	// BindPassword is a password of the service account.
+	BindPassword string
+
+	// BaseDN is a DN of the subtree to search for users and groups.
+	BaseDN string
+
+	// UserFilter is a filter used to find users by their username. %s is replaced with the escaped username.
+	// (default: "(&(objectClass=person)(uid=%s))", use "(&(objectClass=user)(sAMAccountName=%s))" for AD).
+	UserFilter string
+
+	// GroupFilter is a filter used to find groups of the user. %s is replaced with the escaped DN of the user.
+	// (default: "(|(&(objectClass=groupOfNames)(member=%s))(&(objectClass=group)(member=%s)))").
+	GroupFilter string
+
+	// GroupNameAttribute is an attribute of a group entry holding its name (default: "cn").
+	GroupNameAttribute string
+
+	// UserAttributes is a list of attributes to fetch for users (default: all attributes).
+	UserAttributes []string
+
+	// StartTLS specifies whether to upgrade plaintext connections to TLS (default: false).
+	StartTLS bool
+
+	// TLSConfig is an optional TLS configuration used for ldaps:// URLs and StartTLS.
+	TLSConfig *tls.Config
+
+	// PoolSize is a maximum number of idle connections kept in the pool (default: 5).
+	PoolSize int
+
+	// Timeout is a timeout of a single request (default: 5s).
+	Timeout time.Duration
+
+	// DialTimeout is a timeout of establishing a new connection (default: 5s).
+	DialTimeout time.Duration
+}
+
+func mergeConfig(provided *Config) *Config {
+	config := &Config{
+		UserFilter:         "(&(objectClass=person)(uid=%s))",
+		GroupFilter:        "(|(&(objectClass=groupOfNames)(member=%s))(&(objectClass=group)(member=%s)))",
+		GroupNameAttribute: "cn",
+		TLSConfig:          &tls.Config{},
+		PoolSize:           5,
+		Timeout:            5 * time.Second,
+		DialTimeout:        5 * time.Second,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.BindDN != "" {
+		config.BindDN = provided.BindDN
+	}
+	if provided.BindPassword != "" {
+		config.BindPassword = provided.BindPassword
+	}
+	if provided.BaseDN != "" {
+		config.BaseDN = provided.BaseDN
+	}
+	if provided.UserFilter != "" {
+		config.UserFilter = provided.UserFilter
+	}
+	if provided.GroupFilter != "" {
+		config.GroupFilter = provided.GroupFilter
+	}
+	if provided.GroupNameAttribute != "" {
+		config.GroupNameAttribute = provided.GroupNameAttribute
+	}
+	if provided.UserAttributes != nil {
+		config.UserAttributes = provided.UserAttributes
+	}
+	if provided.StartTLS {
+		config.StartTLS = true
+	}
+	if provided.TLSConfig != nil {
+		config.TLSConfig = provided.TLSConfig
+	}
+	if provided.PoolSize > 0 {
+		config.PoolSize = provided.PoolSize
+	}
+	if provided.Timeout > 0 {
+		config.Timeout = provided.Timeout
+	}
+	if provided.DialTimeout > 0 {
+		config.DialTimeout = provided.DialTimeout
+	}
+
+	return config
+}
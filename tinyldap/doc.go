@@ -0,0 +1,4 @@
+/*
+Package tinyldap provides a pooled LDAP client and authorization adapters for tinyhttp and tinygrpc.
+*/
+package tinyldap
@@ -0,0 +1,59 @@
+package tinyldap
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mkorman9/tiny/tinygrpc"
+	"github.com/mkorman9/tiny/tinyhttp/httpauth"
+)
+
+// HTTPVerifier returns a function to be used with httpauth.NewBasicAuthMiddleware.
+// Groups of the user are returned as roles, and *User is stored as session data.
+func (c *Client) HTTPVerifier() httpauth.VerifyCredentialsFunc {
+	return func(_ *fiber.Ctx, username, password string) (*httpauth.VerificationResult, error) {
+		user, err := c.Authenticate(username, password)
+		if err != nil {
+			if errors.Is(err, ErrInvalidCredentials) {
+				return &httpauth.VerificationResult{}, nil
+			}
+
+			return nil, err
+		}
+
+		return &httpauth.VerificationResult{
+			Verified:    true,
+			Roles:       user.Groups,
+			SessionData: user,
+		}, nil
+	}
+}
+
+// GRPCVerifier returns a function to be used with tinygrpc.EnableAuthMiddlewareFunc.
+// The bearer token is expected to be a base64-encoded "username:password" pair, like in HTTP Basic Auth.
+func (c *Client) GRPCVerifier() tinygrpc.TokenVerifierFunc[*User] {
+	return func(token string, _ *tinygrpc.CallMetadata) (*tinygrpc.TokenVerificationResult[*User], error) {
+		decoded, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			return &tinygrpc.TokenVerificationResult[*User]{IsAuthorized: false}, nil
+		}
+
+		username, password, _ := strings.Cut(string(decoded), ":")
+
+		user, err := c.Authenticate(username, password)
+		if err != nil {
+			if errors.Is(err, ErrInvalidCredentials) {
+				return &tinygrpc.TokenVerificationResult[*User]{IsAuthorized: false}, nil
+			}
+
+			return nil, err
+		}
+
+		return &tinygrpc.TokenVerificationResult[*User]{
+			IsAuthorized: true,
+			SessionData:  user,
+		}, nil
+	}
+}
@@ -0,0 +1,62 @@
+package tinylock
+
+import "time"
+
+// RedisConfig holds a configuration for NewRedisLocker.
+type RedisConfig struct {
+	// KeyPrefix is a prefix added to the names of the locks (default: "lock:").
+	KeyPrefix string
+
+	// TTL is a time after which the lock expires unless refreshed (default: 30s).
+	TTL time.Duration
+}
+
+// LeaderConfig holds a configuration for RunWhenLeader.
+type LeaderConfig struct {
+	// RetryInterval is a time between attempts to become a leader (default: 5s).
+	RetryInterval time.Duration
+
+	// RefreshInterval is a time between subsequent refreshes of the leader lock.
+	// When using RedisLocker it should be significantly lower than its TTL (default: 10s).
+	RefreshInterval time.Duration
+}
+
+func mergeRedisConfig(provided *RedisConfig) *RedisConfig {
+	config := &RedisConfig{
+		KeyPrefix: "lock:",
+		TTL:       30 * time.Second,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.KeyPrefix != "" {
+		config.KeyPrefix = provided.KeyPrefix
+	}
+	if provided.TTL > 0 {
+		config.TTL = provided.TTL
+	}
+
+	return config
+}
+
+func mergeLeaderConfig(provided *LeaderConfig) *LeaderConfig {
+	config := &LeaderConfig{
+		RetryInterval:   5 * time.Second,
+		RefreshInterval: 10 * time.Second,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.RetryInterval > 0 {
+		config.RetryInterval = provided.RetryInterval
+	}
+	if provided.RefreshInterval > 0 {
+		config.RefreshInterval = provided.RefreshInterval
+	}
+
+	return config
+}
@@ -0,0 +1,4 @@
+/*
+Package tinylock provides distributed locks and leader election backed by Postgres or Redis.
+*/
+package tinylock
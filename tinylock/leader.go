@@ -0,0 +1,159 @@
+package tinylock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mkorman9/tiny"
+	"github.com/rs/zerolog/log"
+)
+
+type leaderService struct {
+	locker      Locker
+	name        string
+	service     tiny.Service
+	config      *LeaderConfig
+	stopChannel chan struct{}
+	stopOnce    sync.Once
+	doneChannel chan struct{}
+	stateLock   sync.Mutex
+	started     bool
+	stopped     bool
+}
+
+// RunWhenLeader wraps given service, so it's only running on the instance holding the lock with given name.
+// Returned service competes for the lock, starts the wrapped service once the lock is acquired, and stops it
+// when the lock is lost. The wrapped service must support being started again after it has been stopped,
+// otherwise its Start error terminates the wrapper.
+func RunWhenLeader(locker Locker, name string, service tiny.Service, config ...*LeaderConfig) tiny.Service {
+	var providedConfig *LeaderConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeLeaderConfig(providedConfig)
+
+	return &leaderService{
+		locker:      locker,
+		name:        name,
+		service:     service,
+		config:      c,
+		stopChannel: make(chan struct{}),
+		doneChannel: make(chan struct{}),
+	}
+}
+
+// Start implements the interface of tiny.Service.
+func (s *leaderService) Start() error {
+	s.stateLock.Lock()
+	if s.started {
+		s.stateLock.Unlock()
+		return errors.New("leader service already started")
+	}
+	if s.stopped {
+		s.stateLock.Unlock()
+		return nil
+	}
+	s.started = true
+	s.stateLock.Unlock()
+
+	defer close(s.doneChannel)
+
+	for {
+		lock, err := s.waitForLeadership()
+		if err != nil {
+			return err
+		}
+		if lock == nil {
+			return nil
+		}
+
+		log.Info().Msgf("Acquired leadership (%s)", s.name)
+
+		stopped, err := s.lead(lock)
+
+		if err := lock.Unlock(context.Background()); err != nil {
+			log.Warn().Err(err).Msgf("Failed to release leader lock (%s)", s.name)
+		}
+
+		if stopped || err != nil {
+			return err
+		}
+
+		log.Warn().Msgf("Lost leadership (%s)", s.name)
+	}
+}
+
+// Stop implements the interface of tiny.Service.
+func (s *leaderService) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChannel)
+	})
+
+	s.stateLock.Lock()
+	s.stopped = true
+	started := s.started
+	s.stateLock.Unlock()
+
+	if started {
+		<-s.doneChannel
+	}
+}
+
+func (s *leaderService) waitForLeadership() (Lock, error) {
+	for {
+		lock, err := s.locker.TryLock(context.Background(), s.name)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to acquire leader lock (%s)", s.name)
+		} else if lock != nil {
+			return lock, nil
+		}
+
+		select {
+		case <-s.stopChannel:
+			return nil, nil
+		case <-time.After(s.config.RetryInterval):
+		}
+	}
+}
+
+func (s *leaderService) lead(lock Lock) (bool, error) {
+	errorChannel := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errorChannel <- fmt.Errorf("%v", r)
+			}
+		}()
+
+		errorChannel <- s.service.Start()
+	}()
+
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-errorChannel:
+			return true, err
+		case <-s.stopChannel:
+			s.service.Stop()
+			return true, <-errorChannel
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), s.config.RefreshInterval)
+			err := lock.Refresh(ctx)
+			cancel()
+
+			if err != nil {
+				log.Error().Err(err).Msgf("Failed to refresh leader lock (%s)", s.name)
+
+				s.service.Stop()
+				<-errorChannel
+				return false, nil
+			}
+		}
+	}
+}
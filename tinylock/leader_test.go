@@ -0,0 +1,154 @@
+package tinylock
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeLocker struct {
+	available atomic.Bool
+	lost      atomic.Bool
+}
+
+type fakeLock struct {
+	locker *fakeLocker
+}
+
+func (l *fakeLocker) TryLock(_ context.Context, _ string) (Lock, error) {
+	if !l.available.CompareAndSwap(true, false) {
+		return nil, nil
+	}
+
+	l.lost.Store(false)
+	return &fakeLock{locker: l}, nil
+}
+
+func (l *fakeLock) Refresh(_ context.Context) error {
+	if l.locker.lost.Load() {
+		return ErrLockLost
+	}
+
+	return nil
+}
+
+func (l *fakeLock) Unlock(_ context.Context) error {
+	return nil
+}
+
+type fakeService struct {
+	lock        sync.Mutex
+	starts      int
+	stops       int
+	stopChannel chan struct{}
+	started     chan struct{}
+}
+
+func newFakeService() *fakeService {
+	return &fakeService{started: make(chan struct{}, 10)}
+}
+
+func (s *fakeService) Start() error {
+	s.lock.Lock()
+	s.starts++
+	stopChannel := make(chan struct{})
+	s.stopChannel = stopChannel
+	s.lock.Unlock()
+
+	s.started <- struct{}{}
+	<-stopChannel
+	return nil
+}
+
+func (s *fakeService) Stop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.stops++
+	close(s.stopChannel)
+}
+
+func (s *fakeService) counts() (int, int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.starts, s.stops
+}
+
+var testLeaderConfig = &LeaderConfig{
+	RetryInterval:   time.Millisecond,
+	RefreshInterval: time.Millisecond,
+}
+
+func TestRunWhenLeaderRestartsAfterLostLock(t *testing.T) {
+	// given
+	locker := &fakeLocker{}
+	locker.available.Store(true)
+	service := newFakeService()
+	leader := RunWhenLeader(locker, "leader", service, testLeaderConfig)
+
+	result := make(chan error, 1)
+	go func() {
+		result <- leader.Start()
+	}()
+	<-service.started
+
+	// when
+	locker.lost.Store(true)
+	time.Sleep(20 * time.Millisecond)
+	startsAfterLoss, stopsAfterLoss := service.counts()
+
+	locker.available.Store(true)
+	<-service.started
+
+	leader.Stop()
+
+	// then
+	assert.Equal(t, 1, startsAfterLoss, "service should not be restarted without the lock")
+	assert.Equal(t, 1, stopsAfterLoss, "service should be stopped when the lock is lost")
+	starts, stops := service.counts()
+	assert.Equal(t, 2, starts, "service should be restarted after reacquiring the lock")
+	assert.Equal(t, 2, stops, "service should be stopped by Stop")
+	assert.NoError(t, <-result)
+}
+
+func TestRunWhenLeaderStopWithoutLeadership(t *testing.T) {
+	// given
+	locker := &fakeLocker{}
+	service := newFakeService()
+	leader := RunWhenLeader(locker, "leader", service, testLeaderConfig)
+
+	result := make(chan error, 1)
+	go func() {
+		result <- leader.Start()
+	}()
+	time.Sleep(5 * time.Millisecond)
+
+	// when
+	leader.Stop()
+
+	// then
+	assert.NoError(t, <-result)
+	starts, _ := service.counts()
+	assert.Equal(t, 0, starts, "service should never be started")
+}
+
+func TestRunWhenLeaderStopBeforeStart(t *testing.T) {
+	// given
+	locker := &fakeLocker{}
+	locker.available.Store(true)
+	service := newFakeService()
+	leader := RunWhenLeader(locker, "leader", service, testLeaderConfig)
+
+	// when
+	leader.Stop()
+	err := leader.Start()
+
+	// then
+	assert.NoError(t, err)
+	starts, _ := service.counts()
+	assert.Equal(t, 0, starts, "service should not be started after Stop")
+}
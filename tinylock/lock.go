@@ -0,0 +1,63 @@
+package tinylock
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrLockLost is returned by Lock.Refresh when the lock is no longer held.
+	ErrLockLost = errors.New("lock has been lost")
+)
+
+// Locker is a provider of distributed locks.
+type Locker interface {
+	// TryLock tries to acquire the lock with given name without waiting.
+	// It returns nil Lock if the lock is currently held by someone else.
+	TryLock(ctx context.Context, name string) (Lock, error)
+}
+
+// Lock represents an acquired distributed lock.
+type Lock interface {
+	// Refresh makes sure the lock is still held and extends its lifetime if the backend requires so.
+	// It returns ErrLockLost if the lock is no longer held.
+	Refresh(ctx context.Context) error
+
+	// Unlock releases the lock.
+	Unlock(ctx context.Context) error
+}
+
+// Acquire waits until the lock with given name is acquired or the context is cancelled.
+// The lock is polled every retryInterval.
+func Acquire(ctx context.Context, locker Locker, name string, retryInterval time.Duration) (Lock, error) {
+	for {
+		lock, err := locker.TryLock(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if lock != nil {
+			return lock, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// WithLock acquires the lock with given name, executes the function and releases the lock.
+func WithLock(ctx context.Context, locker Locker, name string, retryInterval time.Duration, f func() error) error {
+	lock, err := Acquire(ctx, locker, name, retryInterval)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = lock.Unlock(context.Background())
+	}()
+
+	return f()
+}
@@ -0,0 +1,82 @@
+package tinylock
+
+import (
+	"context"
+	"database/sql"
+	"hash/fnv"
+
+	"gorm.io/gorm"
+)
+
+// PostgresLocker is a Locker based on Postgres session-level advisory locks.
+// Each acquired lock holds a dedicated connection from the pool until it's released.
+type PostgresLocker struct {
+	db *gorm.DB
+}
+
+type postgresLock struct {
+	conn *sql.Conn
+	key  int64
+}
+
+// NewPostgresLocker creates new PostgresLocker using given database connection.
+func NewPostgresLocker(db *gorm.DB) *PostgresLocker {
+	return &PostgresLocker{
+		db: db,
+	}
+}
+
+// TryLock implements the interface of Locker.
+func (l *PostgresLocker) TryLock(ctx context.Context, name string) (Lock, error) {
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := lockKey(name)
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	if !acquired {
+		_ = conn.Close()
+		return nil, nil
+	}
+
+	return &postgresLock{
+		conn: conn,
+		key:  key,
+	}, nil
+}
+
+func (l *postgresLock) Refresh(ctx context.Context) error {
+	// advisory lock lives as long as the session, so it's enough to check whether the connection is still alive
+	if err := l.conn.PingContext(ctx); err != nil {
+		return ErrLockLost
+	}
+
+	return nil
+}
+
+func (l *postgresLock) Unlock(ctx context.Context) error {
+	defer func() {
+		_ = l.conn.Close()
+	}()
+
+	_, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.key)
+	return err
+}
+
+func lockKey(name string) int64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(name))
+	return int64(hash.Sum64())
+}
@@ -0,0 +1,96 @@
+package tinylock
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/mkorman9/tiny"
+)
+
+var (
+	refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+	unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+)
+
+// RedisLocker is a Locker based on Redis keys with expiration time.
+// Acquired locks must be refreshed within TTL, or they expire.
+type RedisLocker struct {
+	client *redis.Client
+	config *RedisConfig
+}
+
+type redisLock struct {
+	client *redis.Client
+	key    string
+	token  string
+	ttl    time.Duration
+}
+
+// NewRedisLocker creates new RedisLocker using given client.
+func NewRedisLocker(client *redis.Client, config ...*RedisConfig) *RedisLocker {
+	var providedConfig *RedisConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeRedisConfig(providedConfig)
+
+	return &RedisLocker{
+		client: client,
+		config: c,
+	}
+}
+
+// TryLock implements the interface of Locker.
+func (l *RedisLocker) TryLock(ctx context.Context, name string) (Lock, error) {
+	token, err := tiny.GetSecureRandomString(16)
+	if err != nil {
+		return nil, err
+	}
+
+	key := l.config.KeyPrefix + name
+
+	acquired, err := l.client.SetNX(ctx, key, token, l.config.TTL).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if !acquired {
+		return nil, nil
+	}
+
+	return &redisLock{
+		client: l.client,
+		key:    key,
+		token:  token,
+		ttl:    l.config.TTL,
+	}, nil
+}
+
+func (l *redisLock) Refresh(ctx context.Context) error {
+	result, err := refreshScript.Run(ctx, l.client, []string{l.key}, l.token, l.ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+
+	if result == 0 {
+		return ErrLockLost
+	}
+
+	return nil
+}
+
+func (l *redisLock) Unlock(ctx context.Context) error {
+	return unlockScript.Run(ctx, l.client, []string{l.key}, l.token).Err()
+}
@@ -0,0 +1,67 @@
+package tinyudp
+
+import "time"
+
+// ServerConfig holds a configuration for NewServer.
+type ServerConfig struct {
+	// Network is a network type of the listener, either "udp", "udp4" or "udp6" (default: "udp").
+	Network string
+
+	// MaxDatagramSize is a size of the buffer datagrams are read into.
+	// Longer datagrams are truncated (default: 65535).
+	MaxDatagramSize int
+
+	// Workers is a number of goroutines handling the datagrams. Zero value means the datagrams are handled
+	// sequentially, on the goroutine reading them (default: 0).
+	Workers int
+
+	// QueueSize is a capacity of the queue of datagrams waiting for the workers.
+	// Datagrams received when the queue is full are dropped (default: 1024).
+	QueueSize int
+
+	// ReadBufferSize is a size of the operating system's receive buffer of the socket (default: system default).
+	ReadBufferSize int
+
+	// WriteBufferSize is a size of the operating system's send buffer of the socket (default: system default).
+	WriteBufferSize int
+
+	// TickerInterval is an interval between subsequent updates of the per-second metrics (default: 1s).
+	TickerInterval time.Duration
+}
+
+func mergeServerConfig(provided *ServerConfig) *ServerConfig {
+	config := &ServerConfig{
+		Network:         "udp",
+		MaxDatagramSize: 65535,
+		QueueSize:       1024,
+		TickerInterval:  time.Second,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.Network != "" {
+		config.Network = provided.Network
+	}
+	if provided.MaxDatagramSize > 0 {
+		config.MaxDatagramSize = provided.MaxDatagramSize
+	}
+	if provided.Workers > 0 {
+		config.Workers = provided.Workers
+	}
+	if provided.QueueSize > 0 {
+		config.QueueSize = provided.QueueSize
+	}
+	if provided.ReadBufferSize > 0 {
+		config.ReadBufferSize = provided.ReadBufferSize
+	}
+	if provided.WriteBufferSize > 0 {
+		config.WriteBufferSize = provided.WriteBufferSize
+	}
+	if provided.TickerInterval > 0 {
+		config.TickerInterval = provided.TickerInterval
+	}
+
+	return config
+}
@@ -0,0 +1,4 @@
+/*
+Package tinyudp provides UDP datagram server implementation.
+*/
+package tinyudp
@@ -0,0 +1,353 @@
+package tinyudp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mkorman9/tiny"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrServerNotStarted is returned when writing through a Server that is not started.
+var ErrServerNotStarted = errors.New("server is not started")
+
+// DatagramHandler is a function handling received datagrams.
+type DatagramHandler = func(datagram *Datagram)
+
+// Datagram is a single datagram received by the Server.
+type Datagram struct {
+	// Data is a content of the datagram.
+	Data []byte
+
+	// Addr is an address of the sender.
+	Addr *net.UDPAddr
+
+	server *Server
+}
+
+// Reply sends data back to the sender of the datagram.
+func (d *Datagram) Reply(data []byte) error {
+	return d.server.WriteTo(d.Addr, data)
+}
+
+// ServerMetrics contains metrics collected by the Server.
+type ServerMetrics struct {
+	// DatagramsReceived is a total number of datagrams received.
+	DatagramsReceived uint64
+
+	// DatagramsSent is a total number of datagrams sent.
+	DatagramsSent uint64
+
+	// DatagramsDropped is a total number of datagrams dropped because the queue of the workers was full.
+	DatagramsDropped uint64
+
+	// BytesReceived is a total number of bytes received.
+	BytesReceived uint64
+
+	// BytesSent is a total number of bytes sent.
+	BytesSent uint64
+
+	// ReadErrors is a total number of errors encountered while reading the socket.
+	ReadErrors uint64
+
+	// DatagramsReceivedPerSecond is a number of datagrams received during the last second.
+	DatagramsReceivedPerSecond uint64
+
+	// BytesReceivedPerSecond is a number of bytes received during the last second.
+	BytesReceivedPerSecond uint64
+
+	// BytesSentPerSecond is a number of bytes sent during the last second.
+	BytesSentPerSecond uint64
+}
+
+// Server is an object representing UDP server and implementing the tiny.Service interface.
+// Server implements tiny.ReadinessNotifier - it becomes ready when it's bound to the port.
+type Server struct {
+	address      string
+	config       *ServerConfig
+	handler      DatagramHandler
+	conn         *net.UDPConn
+	connLock     sync.RWMutex
+	pool         *tiny.WorkerPool
+	readyChannel chan struct{}
+	stopChannel  chan struct{}
+	stopOnce     sync.Once
+	stopped      atomic.Bool
+
+	datagramsReceived atomic.Uint64
+	datagramsSent     atomic.Uint64
+	datagramsDropped  atomic.Uint64
+	bytesReceived     atomic.Uint64
+	bytesSent         atomic.Uint64
+	readErrors        atomic.Uint64
+
+	metrics     ServerMetrics
+	metricsLock sync.RWMutex
+}
+
+// NewServer creates new Server instance.
+func NewServer(address string, config ...*ServerConfig) *Server {
+	var providedConfig *ServerConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeServerConfig(providedConfig)
+
+	server := &Server{
+		address:      address,
+		config:       c,
+		readyChannel: make(chan struct{}),
+		stopChannel:  make(chan struct{}),
+	}
+
+	if c.Workers > 0 {
+		server.pool = tiny.NewWorkerPool(c.Workers, c.QueueSize)
+	}
+
+	return server
+}
+
+// OnDatagram sets a handler for received datagrams.
+func (s *Server) OnDatagram(handler DatagramHandler) {
+	s.handler = handler
+}
+
+// Ready implements the interface of tiny.ReadinessNotifier.
+func (s *Server) Ready() <-chan struct{} {
+	return s.readyChannel
+}
+
+// Address returns the address the server has been created with.
+func (s *Server) Address() string {
+	return s.address
+}
+
+// LocalAddr returns the address the server is bound to, or nil if it's not started.
+func (s *Server) LocalAddr() net.Addr {
+	s.connLock.RLock()
+	defer s.connLock.RUnlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	return s.conn.LocalAddr()
+}
+
+// Metrics returns current metrics of the server.
+func (s *Server) Metrics() ServerMetrics {
+	s.metricsLock.RLock()
+	metrics := s.metrics
+	s.metricsLock.RUnlock()
+
+	metrics.DatagramsReceived = s.datagramsReceived.Load()
+	metrics.DatagramsSent = s.datagramsSent.Load()
+	metrics.DatagramsDropped = s.datagramsDropped.Load()
+	metrics.BytesReceived = s.bytesReceived.Load()
+	metrics.BytesSent = s.bytesSent.Load()
+	metrics.ReadErrors = s.readErrors.Load()
+
+	return metrics
+}
+
+// WriteTo sends a datagram to given address.
+func (s *Server) WriteTo(addr *net.UDPAddr, data []byte) error {
+	s.connLock.RLock()
+	conn := s.conn
+	s.connLock.RUnlock()
+
+	if conn == nil {
+		return ErrServerNotStarted
+	}
+
+	n, err := conn.WriteToUDP(data, addr)
+	if err != nil {
+		return err
+	}
+
+	s.datagramsSent.Add(1)
+	s.bytesSent.Add(uint64(n))
+
+	return nil
+}
+
+// Start implements the interface of tiny.Service.
+func (s *Server) Start() error {
+	if s.handler == nil {
+		return errors.New("datagram handler is not set")
+	}
+
+	addr, err := net.ResolveUDPAddr(s.config.Network, s.address)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.ListenUDP(s.config.Network, addr)
+	if err != nil {
+		return err
+	}
+
+	if err := s.configureConn(conn); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	s.connLock.Lock()
+	s.conn = conn
+	s.connLock.Unlock()
+
+	if s.stopped.Load() {
+		_ = conn.Close()
+		return nil
+	}
+
+	if s.pool != nil {
+		go func() {
+			_ = s.pool.Start()
+		}()
+	}
+
+	go s.startTicker()
+
+	close(s.readyChannel)
+	log.Info().Msgf("UDP server started (%s)", s.address)
+
+	return s.readLoop(conn)
+}
+
+// Stop implements the interface of tiny.Service.
+// Stop waits until the datagrams already queued for the workers are handled.
+func (s *Server) Stop() {
+	s.stopOnce.Do(func() {
+		s.stopped.Store(true)
+		close(s.stopChannel)
+
+		s.connLock.RLock()
+		if s.conn != nil {
+			_ = s.conn.Close()
+		}
+		s.connLock.RUnlock()
+
+		if s.pool != nil {
+			s.pool.Stop()
+		}
+
+		log.Info().Msgf("UDP server stopped (%s)", s.address)
+	})
+}
+
+func (s *Server) configureConn(conn *net.UDPConn) error {
+	if s.config.ReadBufferSize > 0 {
+		if err := conn.SetReadBuffer(s.config.ReadBufferSize); err != nil {
+			return err
+		}
+	}
+	if s.config.WriteBufferSize > 0 {
+		if err := conn.SetWriteBuffer(s.config.WriteBufferSize); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) readLoop(conn *net.UDPConn) error {
+	buffer := make([]byte, s.config.MaxDatagramSize)
+
+	for {
+		n, addr, err := conn.ReadFromUDP(buffer)
+		if err != nil {
+			if s.stopped.Load() {
+				return nil
+			}
+
+			s.readErrors.Add(1)
+
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return err
+			}
+
+			log.Error().Err(err).Msgf("Error reading UDP socket (%s)", s.address)
+			continue
+		}
+
+		s.datagramsReceived.Add(1)
+		s.bytesReceived.Add(uint64(n))
+
+		data := make([]byte, n)
+		copy(data, buffer[:n])
+
+		datagram := &Datagram{
+			Data:   data,
+			Addr:   addr,
+			server: s,
+		}
+
+		if s.pool == nil {
+			s.handle(datagram)
+			continue
+		}
+
+		if err := s.pool.TrySubmit(func() { s.handle(datagram) }); err != nil {
+			s.datagramsDropped.Add(1)
+		}
+	}
+}
+
+func (s *Server) handle(datagram *Datagram) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().
+				Stack().
+				Err(fmt.Errorf("%v", r)).
+				Msgf("Panic while handling UDP datagram (%s)", s.address)
+		}
+	}()
+
+	s.handler(datagram)
+}
+
+func (s *Server) startTicker() {
+	ticker := time.NewTicker(s.config.TickerInterval)
+	defer ticker.Stop()
+
+	var lastDatagramsReceived, lastBytesReceived, lastBytesSent uint64
+	lastTick := time.Now()
+
+	for {
+		select {
+		case <-s.stopChannel:
+			return
+		case now := <-ticker.C:
+			datagramsReceived := s.datagramsReceived.Load()
+			bytesReceived := s.bytesReceived.Load()
+			bytesSent := s.bytesSent.Load()
+			elapsed := now.Sub(lastTick).Seconds()
+
+			s.metricsLock.Lock()
+			s.metrics.DatagramsReceivedPerSecond = perSecond(datagramsReceived-lastDatagramsReceived, elapsed)
+			s.metrics.BytesReceivedPerSecond = perSecond(bytesReceived-lastBytesReceived, elapsed)
+			s.metrics.BytesSentPerSecond = perSecond(bytesSent-lastBytesSent, elapsed)
+			s.metricsLock.Unlock()
+
+			lastDatagramsReceived, lastBytesReceived, lastBytesSent = datagramsReceived, bytesReceived, bytesSent
+			lastTick = now
+		}
+	}
+}
+
+func perSecond(value uint64, elapsed float64) uint64 {
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return uint64(float64(value) / elapsed)
+}
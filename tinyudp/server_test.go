@@ -0,0 +1,38 @@
+package tinyudp
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestServerReply(t *testing.T) {
+	// given
+	server := NewServer("127.0.0.1:0", &ServerConfig{Workers: 2})
+	server.OnDatagram(func(datagram *Datagram) {
+		_ = datagram.Reply(append([]byte("echo: "), datagram.Data...))
+	})
+
+	go func() {
+		_ = server.Start()
+	}()
+	defer server.Stop()
+	<-server.Ready()
+
+	client, err := net.DialUDP("udp", nil, server.LocalAddr().(*net.UDPAddr))
+	assert.Nil(t, err, "client should connect")
+	defer client.Close()
+
+	// when
+	_, _ = client.Write([]byte("hello"))
+
+	// then
+	_ = client.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buffer := make([]byte, 64)
+	n, err := client.Read(buffer)
+
+	assert.Nil(t, err, "reply should be received")
+	assert.Equal(t, "echo: hello", string(buffer[:n]), "reply should contain the datagram")
+	assert.Equal(t, uint64(1), server.Metrics().DatagramsReceived, "datagram should be counted")
+}
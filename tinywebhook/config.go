@@ -0,0 +1,84 @@
+package tinywebhook
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mkorman9/tiny/tinyhttp/requests"
+)
+
+// DispatcherConfig holds a configuration for NewDispatcher.
+type DispatcherConfig struct {
+	// JobName is a name of the tinyjobs job used to deliver webhooks (default: "tinywebhook.deliver").
+	JobName string
+
+	// MaxAttempts is a maximum number of delivery attempts before the delivery is dead-lettered (default: 10).
+	MaxAttempts int
+
+	// UserAgent is a value of User-Agent header sent with each delivery (default: "tinywebhook").
+	UserAgent string
+
+	// OnDelivery is an optional hook called after each delivery attempt, for example to export metrics.
+	OnDelivery func(delivery *Delivery)
+
+	// Client is a configuration of the underlying HTTP client.
+	Client *requests.Config
+}
+
+// VerifierConfig holds a configuration for NewVerifier.
+type VerifierConfig struct {
+	// Tolerance is a maximum allowed difference between the signature timestamp and the current time (default: 5m).
+	Tolerance time.Duration
+
+	// OnInvalid is an optional handler called when the signature is invalid (default: respond with 401).
+	OnInvalid func(c *fiber.Ctx, err error) error
+}
+
+func mergeDispatcherConfig(provided *DispatcherConfig) *DispatcherConfig {
+	config := &DispatcherConfig{
+		JobName:     "tinywebhook.deliver",
+		MaxAttempts: 10,
+		UserAgent:   "tinywebhook",
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.JobName != "" {
+		config.JobName = provided.JobName
+	}
+	if provided.MaxAttempts > 0 {
+		config.MaxAttempts = provided.MaxAttempts
+	}
+	if provided.UserAgent != "" {
+		config.UserAgent = provided.UserAgent
+	}
+	if provided.OnDelivery != nil {
+		config.OnDelivery = provided.OnDelivery
+	}
+	if provided.Client != nil {
+		config.Client = provided.Client
+	}
+
+	return config
+}
+
+func mergeVerifierConfig(provided *VerifierConfig) *VerifierConfig {
+	config := &VerifierConfig{
+		Tolerance: 5 * time.Minute,
+	}
+
+	if provided == nil {
+		return config
+	}
+
+	if provided.Tolerance > 0 {
+		config.Tolerance = provided.Tolerance
+	}
+	if provided.OnInvalid != nil {
+		config.OnInvalid = provided.OnInvalid
+	}
+
+	return config
+}
@@ -0,0 +1,285 @@
+package tinywebhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mkorman9/tiny"
+	"github.com/mkorman9/tiny/tinyhttp/requests"
+	"github.com/mkorman9/tiny/tinyjobs"
+	"github.com/rs/zerolog/log"
+)
+
+// Endpoint is a receiver of webhooks.
+type Endpoint struct {
+	// ID uniquely identifies the endpoint.
+	ID string
+
+	// URL is an address the events are POSTed to.
+	URL string
+
+	// Secret is a key used to sign the deliveries.
+	Secret string
+
+	// Events is a list of event names the endpoint is subscribed to. Empty list means all the events.
+	Events []string
+
+	// Headers is a set of additional headers sent with each delivery.
+	Headers map[string]string
+}
+
+// Delivery describes a single attempt to deliver an event to an endpoint.
+type Delivery struct {
+	// EndpointID is an identifier of the target endpoint.
+	EndpointID string
+
+	// EventID is a unique identifier of the event.
+	EventID string
+
+	// Event is a name of the event.
+	Event string
+
+	// Attempt is a number of the attempt, starting from 1.
+	Attempt int
+
+	// StatusCode is a status code returned by the endpoint, or 0 if no response has been received.
+	StatusCode int
+
+	// Duration is a time the attempt took.
+	Duration time.Duration
+
+	// Err is an error that caused the attempt to fail, or nil on success.
+	Err error
+
+	// DeadLettered is true when the attempt failed and no more retries will be made.
+	DeadLettered bool
+}
+
+// DispatcherMetrics holds statistics of the Dispatcher.
+type DispatcherMetrics struct {
+	// Dispatched is a total number of deliveries enqueued.
+	Dispatched uint64
+
+	// Delivered is a total number of successful deliveries.
+	Delivered uint64
+
+	// Failed is a total number of failed delivery attempts.
+	Failed uint64
+
+	// DeadLettered is a total number of deliveries abandoned after exceeding the retry limit.
+	DeadLettered uint64
+}
+
+// Dispatcher sends signed events to the registered endpoints.
+// Deliveries are stored in a tinyjobs.Queue, so they survive restarts and are retried with exponential backoff
+// configured on the tinyjobs.Worker. Deliveries exceeding the retry limit are moved to the dead-letter table.
+type Dispatcher struct {
+	queue          *tinyjobs.Queue
+	config         *DispatcherConfig
+	client         *requests.Client
+	endpoints      map[string]*Endpoint
+	endpointsMutex sync.RWMutex
+	metrics        dispatcherMetrics
+}
+
+type dispatcherMetrics struct {
+	dispatched   uint64
+	delivered    uint64
+	failed       uint64
+	deadLettered uint64
+}
+
+type deliveryPayload struct {
+	EndpointID string          `json:"endpointId"`
+	EventID    string          `json:"eventId"`
+	Event      string          `json:"event"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// NewDispatcher creates new Dispatcher storing deliveries in given queue.
+// Deliveries are not sent until the Dispatcher is attached to a worker with Attach.
+func NewDispatcher(queue *tinyjobs.Queue, config ...*DispatcherConfig) *Dispatcher {
+	var providedConfig *DispatcherConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeDispatcherConfig(providedConfig)
+
+	return &Dispatcher{
+		queue:     queue,
+		config:    c,
+		client:    requests.NewClient(c.Client),
+		endpoints: map[string]*Endpoint{},
+	}
+}
+
+// Register adds an endpoint or replaces an existing one with the same ID.
+func (d *Dispatcher) Register(endpoint *Endpoint) {
+	d.endpointsMutex.Lock()
+	defer d.endpointsMutex.Unlock()
+
+	d.endpoints[endpoint.ID] = endpoint
+}
+
+// Unregister removes an endpoint. Pending deliveries to the endpoint are dropped.
+func (d *Dispatcher) Unregister(id string) {
+	d.endpointsMutex.Lock()
+	defer d.endpointsMutex.Unlock()
+
+	delete(d.endpoints, id)
+}
+
+// Attach registers the delivery handler on given worker.
+func (d *Dispatcher) Attach(worker *tinyjobs.Worker) {
+	worker.Handle(d.config.JobName, d.deliver)
+}
+
+// Dispatch enqueues a delivery of the event to all the endpoints subscribed to it.
+// Payload is encoded as JSON. Returns a unique identifier of the event.
+func (d *Dispatcher) Dispatch(event string, payload any) (string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	eventID, err := tiny.GetSecureRandomString(16)
+	if err != nil {
+		return "", err
+	}
+
+	for _, endpoint := range d.subscribers(event) {
+		_, err := d.queue.Enqueue(
+			d.config.JobName,
+			&deliveryPayload{
+				EndpointID: endpoint.ID,
+				EventID:    eventID,
+				Event:      event,
+				Body:       body,
+			},
+			tinyjobs.MaxAttempts(d.config.MaxAttempts),
+			tinyjobs.Unique(eventID+":"+endpoint.ID),
+		)
+		if err != nil {
+			return eventID, err
+		}
+
+		atomic.AddUint64(&d.metrics.dispatched, 1)
+	}
+
+	return eventID, nil
+}
+
+// Metrics returns current statistics of the Dispatcher.
+func (d *Dispatcher) Metrics() DispatcherMetrics {
+	return DispatcherMetrics{
+		Dispatched:   atomic.LoadUint64(&d.metrics.dispatched),
+		Delivered:    atomic.LoadUint64(&d.metrics.delivered),
+		Failed:       atomic.LoadUint64(&d.metrics.failed),
+		DeadLettered: atomic.LoadUint64(&d.metrics.deadLettered),
+	}
+}
+
+func (d *Dispatcher) subscribers(event string) []*Endpoint {
+	d.endpointsMutex.RLock()
+	defer d.endpointsMutex.RUnlock()
+
+	var endpoints []*Endpoint
+	for _, endpoint := range d.endpoints {
+		if endpoint.Events == nil {
+			endpoints = append(endpoints, endpoint)
+			continue
+		}
+
+		for _, e := range endpoint.Events {
+			if e == event {
+				endpoints = append(endpoints, endpoint)
+				break
+			}
+		}
+	}
+
+	return endpoints
+}
+
+func (d *Dispatcher) deliver(job *tinyjobs.Job) error {
+	var payload deliveryPayload
+	if err := job.Bind(&payload); err != nil {
+		return err
+	}
+
+	d.endpointsMutex.RLock()
+	endpoint, ok := d.endpoints[payload.EndpointID]
+	d.endpointsMutex.RUnlock()
+
+	if !ok {
+		log.Warn().Msgf("Dropping webhook %s for unknown endpoint %s", payload.EventID, payload.EndpointID)
+		return nil
+	}
+
+	delivery := &Delivery{
+		EndpointID: endpoint.ID,
+		EventID:    payload.EventID,
+		Event:      payload.Event,
+		Attempt:    job.Attempts,
+	}
+
+	startTime := time.Now()
+	delivery.StatusCode, delivery.Err = d.send(endpoint, &payload)
+	delivery.Duration = time.Since(startTime)
+
+	if delivery.Err == nil {
+		atomic.AddUint64(&d.metrics.delivered, 1)
+	} else {
+		atomic.AddUint64(&d.metrics.failed, 1)
+
+		if job.Attempts >= job.MaxAttempts {
+			delivery.DeadLettered = true
+			atomic.AddUint64(&d.metrics.deadLettered, 1)
+		}
+	}
+
+	if d.config.OnDelivery != nil {
+		d.config.OnDelivery(delivery)
+	}
+
+	return delivery.Err
+}
+
+func (d *Dispatcher) send(endpoint *Endpoint, payload *deliveryPayload) (int, error) {
+	opts := []requests.RequestOpt{
+		requests.Method("POST"),
+		requests.Body(bytes.NewReader(payload.Body)),
+		requests.ContentType("application/json"),
+		requests.UserAgent(d.config.UserAgent),
+		requests.Header(SignatureHeader, Sign(endpoint.Secret, payload.Body, time.Now())),
+		requests.Header(EventHeader, payload.Event),
+		requests.Header(IDHeader, payload.EventID),
+	}
+	for key, value := range endpoint.Headers {
+		opts = append(opts, requests.Header(key, value))
+	}
+
+	request, err := requests.NewRequest(endpoint.URL, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := d.client.Send(request)
+	if err != nil {
+		return 0, err
+	}
+
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("endpoint returned status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
@@ -0,0 +1,5 @@
+/*
+Package tinywebhook provides a dispatcher of signed outbound webhooks with durable retries, and a verifier
+for incoming webhooks.
+*/
+package tinywebhook
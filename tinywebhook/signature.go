@@ -0,0 +1,88 @@
+package tinywebhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader is a name of the header carrying the signature of a delivery.
+	SignatureHeader = "X-Webhook-Signature"
+
+	// EventHeader is a name of the header carrying the name of the event.
+	EventHeader = "X-Webhook-Event"
+
+	// IDHeader is a name of the header carrying the unique identifier of the event.
+	IDHeader = "X-Webhook-ID"
+)
+
+var (
+	// ErrInvalidSignature is returned when the signature is malformed or does not match the body.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+
+	// ErrSignatureExpired is returned when the signature timestamp is outside the allowed tolerance.
+	ErrSignatureExpired = errors.New("webhook signature expired")
+)
+
+// Sign computes a value of the signature header for given body, in "t=<unix timestamp>,v1=<hex HMAC-SHA256>" format.
+// The HMAC is computed over "<timestamp>.<body>".
+func Sign(secret string, body []byte, timestamp time.Time) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + ts + ",v1=" + computeMAC(secret, ts, body)
+}
+
+// VerifySignature checks whether the signature header matches given body and was created within the tolerance.
+func VerifySignature(secret string, header string, body []byte, tolerance time.Duration) error {
+	var ts string
+	var signatures []string
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	if ts == "" || signatures == nil {
+		return ErrInvalidSignature
+	}
+
+	timestamp, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if tolerance > 0 && math.Abs(float64(time.Now().Unix()-timestamp)) > tolerance.Seconds() {
+		return ErrSignatureExpired
+	}
+
+	expected := computeMAC(secret, ts, body)
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
+
+func computeMAC(secret, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,26 @@
+package tinywebhook
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSignatureVerification(t *testing.T) {
+	// given
+	body := []byte(`{"orderId":123}`)
+	header := Sign("secret", body, time.Now())
+	expiredHeader := Sign("secret", body, time.Now().Add(-time.Hour))
+
+	// when
+	validErr := VerifySignature("secret", header, body, time.Minute)
+	wrongSecretErr := VerifySignature("other", header, body, time.Minute)
+	tamperedErr := VerifySignature("secret", header, []byte(`{"orderId":124}`), time.Minute)
+	expiredErr := VerifySignature("secret", expiredHeader, body, time.Minute)
+
+	// then
+	assert.Nil(t, validErr, "valid signature should be accepted")
+	assert.ErrorIs(t, wrongSecretErr, ErrInvalidSignature, "signature with a different secret should be rejected")
+	assert.ErrorIs(t, tamperedErr, ErrInvalidSignature, "signature of a tampered body should be rejected")
+	assert.ErrorIs(t, expiredErr, ErrSignatureExpired, "expired signature should be rejected")
+}
@@ -0,0 +1,42 @@
+package tinywebhook
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewVerificationMiddleware creates a Fiber middleware rejecting requests without a valid webhook signature.
+// Requests with an invalid signature are passed to VerifierConfig.OnInvalid or rejected with 401.
+func NewVerificationMiddleware(secret string, config ...*VerifierConfig) fiber.Handler {
+	var providedConfig *VerifierConfig
+	if config != nil {
+		providedConfig = config[0]
+	}
+	c := mergeVerifierConfig(providedConfig)
+
+	return func(ctx *fiber.Ctx) error {
+		err := VerifyRequest(ctx, secret, c.Tolerance)
+		if err != nil {
+			if c.OnInvalid != nil {
+				return c.OnInvalid(ctx, err)
+			}
+
+			ctx.Status(fiber.StatusUnauthorized)
+			return nil
+		}
+
+		return ctx.Next()
+	}
+}
+
+// VerifyRequest checks the signature of the webhook received in given request.
+func VerifyRequest(ctx *fiber.Ctx, secret string, tolerance time.Duration) error {
+	header := ctx.Get(SignatureHeader)
+	if header == "" {
+		return errors.New("missing webhook signature")
+	}
+
+	return VerifySignature(secret, header, ctx.Body(), tolerance)
+}
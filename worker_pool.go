@@ -0,0 +1,161 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrWorkerPoolStopped is returned when a task is submitted to a stopped WorkerPool.
+	ErrWorkerPoolStopped = errors.New("worker pool is stopped")
+
+	// ErrWorkerPoolFull is returned by TrySubmit when the queue of the WorkerPool is full.
+	ErrWorkerPoolFull = errors.New("worker pool queue is full")
+)
+
+// Task is a unit of work executed by WorkerPool.
+type Task = func()
+
+// WorkerPool is a Service executing submitted tasks with a fixed number of workers.
+// Tasks are buffered in a queue of limited capacity. When the queue is full, Submit blocks until there is space.
+// A panic in a task is recovered and logged, without affecting other tasks.
+// On Stop, the pool stops accepting new tasks and waits until all the queued ones are executed.
+// Tasks queued in a pool that has never been started are discarded.
+type WorkerPool struct {
+	size        int
+	tasks       chan Task
+	tasksLock   sync.RWMutex
+	closed      bool
+	stopping    bool
+	stateLock   sync.Mutex
+	wg          sync.WaitGroup
+	stopChannel chan struct{}
+	stopOnce    sync.Once
+}
+
+// NewWorkerPool creates new WorkerPool with given number of workers and capacity of the queue.
+func NewWorkerPool(size, queueCapacity int) *WorkerPool {
+	if size < 1 {
+		size = 1
+	}
+	if queueCapacity < 0 {
+		queueCapacity = 0
+	}
+
+	return &WorkerPool{
+		size:        size,
+		tasks:       make(chan Task, queueCapacity),
+		stopChannel: make(chan struct{}),
+	}
+}
+
+// Submit adds a task to the queue, blocking until there is space in it.
+// It returns ErrWorkerPoolStopped if the pool is stopped.
+func (p *WorkerPool) Submit(task Task) error {
+	return p.SubmitContext(context.Background(), task)
+}
+
+// SubmitContext adds a task to the queue, blocking until there is space in it or the context is done.
+func (p *WorkerPool) SubmitContext(ctx context.Context, task Task) error {
+	p.tasksLock.RLock()
+	defer p.tasksLock.RUnlock()
+
+	if p.closed {
+		return ErrWorkerPoolStopped
+	}
+
+	select {
+	case p.tasks <- task:
+		return nil
+	case <-p.stopChannel:
+		return ErrWorkerPoolStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TrySubmit adds a task to the queue without blocking. It returns ErrWorkerPoolFull if the queue is full.
+func (p *WorkerPool) TrySubmit(task Task) error {
+	p.tasksLock.RLock()
+	defer p.tasksLock.RUnlock()
+
+	if p.closed {
+		return ErrWorkerPoolStopped
+	}
+
+	select {
+	case p.tasks <- task:
+		return nil
+	default:
+		return ErrWorkerPoolFull
+	}
+}
+
+// Pending returns a number of tasks waiting in the queue.
+func (p *WorkerPool) Pending() int {
+	return len(p.tasks)
+}
+
+// Start implements the interface of Service.
+func (p *WorkerPool) Start() error {
+	p.stateLock.Lock()
+	if p.stopping {
+		p.stateLock.Unlock()
+		return nil
+	}
+	p.wg.Add(p.size)
+	p.stateLock.Unlock()
+
+	for i := 0; i < p.size; i++ {
+		go p.work()
+	}
+
+	<-p.stopChannel
+	p.wg.Wait()
+
+	return nil
+}
+
+// Stop implements the interface of Service.
+// Stop blocks until all the queued tasks are executed.
+func (p *WorkerPool) Stop() {
+	p.stopOnce.Do(func() {
+		p.stateLock.Lock()
+		p.stopping = true
+		p.stateLock.Unlock()
+
+		close(p.stopChannel)
+
+		p.tasksLock.Lock()
+		p.closed = true
+		close(p.tasks)
+		p.tasksLock.Unlock()
+	})
+
+	p.wg.Wait()
+}
+
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+
+	for task := range p.tasks {
+		p.execute(task)
+	}
+}
+
+func (p *WorkerPool) execute(task Task) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().
+				Stack().
+				Err(fmt.Errorf("%v", r)).
+				Msg("Panic in worker pool task")
+		}
+	}()
+
+	task()
+}
@@ -0,0 +1,33 @@
+package tiny
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWorkerPoolDrainsOnStop(t *testing.T) {
+	// given
+	pool := NewWorkerPool(2, 10)
+	var executed atomic.Int32
+
+	go func() {
+		_ = pool.Start()
+	}()
+
+	for i := 0; i < 20; i++ {
+		_ = pool.Submit(func() {
+			executed.Add(1)
+		})
+	}
+	_ = pool.Submit(func() {
+		panic("task failed")
+	})
+
+	// when
+	pool.Stop()
+
+	// then
+	assert.Equal(t, int32(20), executed.Load(), "all queued tasks should be executed")
+	assert.ErrorIs(t, pool.Submit(func() {}), ErrWorkerPoolStopped, "stopped pool should reject tasks")
+}